@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"time"
+	_ "time/tzdata" // часовые пояса рабочих часов пользователей, в alpine-образе их нет
 
 	"go-project/config"
 	"go-project/internal/application/usecases"
+	"go-project/internal/domain/entities"
 	"go-project/internal/infrastructure/postgres_database/migrations"
 	"go-project/internal/interfaces/httpapi"
 
@@ -31,17 +35,41 @@ func main() {
 	userRepo := repositories.NewUserRepository(db)
 	teamRepo := repositories.NewTeamRepository(db)
 	prRepo := repositories.NewPullRequestRepository(db)
+	ruleRepo := repositories.NewOwnershipRuleRepository(db)
 
 	// Инициализация use cases
-	prUseCase := usecases.NewPullRequestUseCase(prRepo, teamRepo, userRepo)
-	teamUseCase := usecases.NewTeamUseCase(teamRepo, userRepo)
-	userUseCase := usecases.NewUserUseCase(userRepo, teamRepo)
+	strategy := entities.ReviewerStrategy(cfg.ReviewerStrategy)
+	if !strategy.IsValid() {
+		log.Fatalf("Unknown reviewer strategy: %s", cfg.ReviewerStrategy)
+	}
+
+	prUseCase := usecases.NewPullRequestUseCase(prRepo, teamRepo, userRepo, ruleRepo, db, strategy, usecases.SystemClock{})
+	teamUseCase := usecases.NewTeamUseCase(teamRepo, userRepo, prUseCase, db)
+	userUseCase := usecases.NewUserUseCase(userRepo, teamRepo, prUseCase, db)
+	ownershipUseCase := usecases.NewOwnershipUseCase(ruleRepo, teamRepo, userRepo)
+	slaUseCase := usecases.NewReviewSLAUseCase(prRepo, teamRepo, prUseCase, db)
 
 	// Инициализация тестовых данных
 	migrations.InitTestDataViaUseCases(teamUseCase, userRepo)
 
+	// Фоновая обработка просроченных ревью
+	slaInterval, err := time.ParseDuration(cfg.SLACheckInterval)
+	if err != nil || slaInterval <= 0 {
+		log.Fatalf("Invalid SLA check interval: %s", cfg.SLACheckInterval)
+	}
+	go runReviewSLAWorker(slaUseCase, slaInterval)
+
+	// Перераспределение нагрузки по расписанию
+	rebalanceInterval, err := time.ParseDuration(cfg.RebalanceInterval)
+	if err != nil || rebalanceInterval < 0 {
+		log.Fatalf("Invalid rebalance interval: %s", cfg.RebalanceInterval)
+	}
+	if rebalanceInterval > 0 {
+		go runRebalanceWorker(teamUseCase, rebalanceInterval)
+	}
+
 	// Запуск сервера
-	server := httpapi.NewServer(prUseCase, teamUseCase, userUseCase)
+	server := httpapi.NewServer(prUseCase, teamUseCase, userUseCase, ownershipUseCase, slaUseCase)
 
 	addr := fmt.Sprintf(":%s", cfg.AppPort)
 	log.Printf("Server starting on %s", addr)
@@ -49,3 +77,35 @@ func main() {
 		log.Fatal("Server failed to start:", err)
 	}
 }
+
+// Периодически переназначает или эскалирует ревью, просроченные по SLA команд
+func runReviewSLAWorker(slaUseCase *usecases.ReviewSLAUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		escalations, err := slaUseCase.ProcessOverdueReviews(context.Background())
+		if len(escalations) > 0 {
+			log.Printf("Review SLA: processed %d overdue reviews", len(escalations))
+		}
+		if err != nil {
+			log.Printf("Review SLA check failed: %v", err)
+		}
+	}
+}
+
+// Периодически перераспределяет неначатые ревью в командах, включивших auto_rebalance
+func runRebalanceWorker(teamUseCase *usecases.TeamUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		moves, err := teamUseCase.RebalanceAutoTeams(context.Background())
+		if len(moves) > 0 {
+			log.Printf("Rebalance: moved %d reviews", len(moves))
+		}
+		if err != nil {
+			log.Printf("Rebalance failed: %v", err)
+		}
+	}
+}
@@ -14,6 +14,10 @@ type Config struct {
 
 	AppPort string
 
+	ReviewerStrategy  string // стратегия выбора ревьюверов по умолчанию
+	SLACheckInterval  string // как часто проверять просроченные ревью (формат time.ParseDuration)
+	RebalanceInterval string // как часто перераспределять ревью в командах с auto_rebalance ("0" - не перераспределять)
+
 	IsTest bool
 }
 
@@ -26,7 +30,12 @@ func Load() *Config {
 		DBName:     getEnv("DB_NAME", "******"),
 		DBSSLMode:  getEnv("DB_SSL_MODE", "disable"),
 		AppPort:    getEnv("APP_PORT", "8080"),
-		IsTest:     false,
+
+		ReviewerStrategy:  getEnv("REVIEWER_STRATEGY", "least_open_reviews"),
+		SLACheckInterval:  getEnv("SLA_CHECK_INTERVAL", "1m"),
+		RebalanceInterval: getEnv("REBALANCE_INTERVAL", "1h"),
+
+		IsTest: false,
 	}
 }
 
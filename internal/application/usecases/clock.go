@@ -0,0 +1,15 @@
+package usecases
+
+import "time"
+
+// Clock - источник текущего времени, в тестах подменяется фиксированным
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock - системные часы
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
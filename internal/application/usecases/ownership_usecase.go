@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	"go-project/internal/domain/entities"
+	"go-project/internal/domain/errors"
+	"go-project/internal/domain/repositories"
+)
+
+type OwnershipUseCase struct {
+	ruleRepo repositories.OwnershipRuleRepository
+	teamRepo repositories.TeamRepository
+	userRepo repositories.UserRepository
+}
+
+func NewOwnershipUseCase(
+	ruleRepo repositories.OwnershipRuleRepository,
+	teamRepo repositories.TeamRepository,
+	userRepo repositories.UserRepository,
+) *OwnershipUseCase {
+	return &OwnershipUseCase{
+		ruleRepo: ruleRepo,
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+	}
+}
+
+// Импортирует файл CODEOWNERS, полностью заменяя текущие правила
+func (uc *OwnershipUseCase) ImportCodeowners(ctx context.Context, content string) ([]*entities.OwnershipRule, error) {
+	rules, err := ParseCodeowners(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Проверяем, что все владельцы существуют
+	for _, rule := range rules {
+		for _, teamName := range rule.OwnerTeams {
+			if _, err := uc.teamRepo.GetByName(ctx, teamName); err != nil {
+				return nil, errors.NewDomainError(errors.ErrNotFound,
+					fmt.Sprintf("owner team %s not found (pattern %s)", teamName, rule.Pattern))
+			}
+		}
+		for _, userID := range rule.OwnerUsers {
+			if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+				return nil, errors.NewDomainError(errors.ErrNotFound,
+					fmt.Sprintf("owner user %s not found (pattern %s)", userID, rule.Pattern))
+			}
+		}
+	}
+
+	if err := uc.ruleRepo.ReplaceAll(ctx, rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (uc *OwnershipUseCase) GetRules(ctx context.Context) ([]*entities.OwnershipRule, error) {
+	return uc.ruleRepo.GetAll(ctx)
+}
+
+// ParseCodeowners разбирает файл в формате CODEOWNERS.
+// Владелец "@org/team" (или "@team/name") - команда с именем после последнего "/", "@user" - пользователь по ID.
+// Строка только с шаблоном снимает владельцев с путей, подходящих под него.
+// Шаблоны компилируются сразу, неподдерживаемый шаблон отклоняется с номером строки
+func ParseCodeowners(content string) ([]*entities.OwnershipRule, error) {
+	var rules []*entities.OwnershipRule
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		rule := &entities.OwnershipRule{
+			Position:   len(rules),
+			Pattern:    fields[0],
+			OwnerTeams: []string{},
+			OwnerUsers: []string{},
+		}
+		if err := rule.Compile(); err != nil {
+			return nil, errors.NewDomainError(errors.ErrInvalidRequest, fmt.Sprintf("line %d: %v", lineNumber, err))
+		}
+
+		for _, owner := range fields[1:] {
+			if !strings.HasPrefix(owner, "@") || len(owner) == 1 {
+				return nil, errors.NewDomainError(errors.ErrInvalidRequest,
+					fmt.Sprintf("line %d: unsupported owner %q, expected @user or @org/team", lineNumber, owner))
+			}
+
+			name := owner[1:]
+			if i := strings.LastIndex(name, "/"); i >= 0 {
+				rule.OwnerTeams = append(rule.OwnerTeams, name[i+1:])
+			} else {
+				rule.OwnerUsers = append(rule.OwnerUsers, name)
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, err.Error())
+	}
+
+	return rules, nil
+}
+
+// Владельцы измененных путей: для каждого пути берется последнее подходящее правило
+func matchOwners(rules []*entities.OwnershipRule, paths []string) (teams []string, users []string) {
+	for _, path := range paths {
+		var matched *entities.OwnershipRule
+		for _, rule := range rules {
+			if rule.Matches(path) {
+				matched = rule
+			}
+		}
+		if matched == nil {
+			continue
+		}
+
+		for _, teamName := range matched.OwnerTeams {
+			if !contains(teams, teamName) {
+				teams = append(teams, teamName)
+			}
+		}
+		for _, userID := range matched.OwnerUsers {
+			if !contains(users, userID) {
+				users = append(users, userID)
+			}
+		}
+	}
+
+	return teams, users
+}
@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"go-project/internal/domain/errors"
+)
+
+// Размер страницы списков: по умолчанию и наибольший допустимый
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// Проверяет запрошенный размер страницы (0 - по умолчанию)
+func pageSize(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultPageSize, nil
+	}
+	if limit < 0 || limit > MaxPageSize {
+		return 0, errors.NewDomainError(errors.ErrInvalidRequest, "limit must be between 1 and 200")
+	}
+	return limit, nil
+}
+
+// Курсор страницы непрозрачен для клиента: ключ последней записи страницы в JSON и base64
+func encodeCursor(key any) string {
+	data, _ := json.Marshal(key)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Разбирает курсор, выданный encodeCursor, в key ("" - первая страница, key не меняется)
+func decodeCursor(cursor string, key any) error {
+	if cursor == "" {
+		return nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		err = json.Unmarshal(data, key)
+	}
+	if err != nil {
+		return errors.NewDomainError(errors.ErrInvalidRequest, "invalid cursor")
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package usecases
+
+import (
+	"context"
+
+	"go-project/internal/domain/entities"
+	"go-project/internal/domain/errors"
+)
+
+// Черновик готов к ревью: переводим в OPEN и назначаем ревьюверов по сохраненным путям и меткам
+func (uc *PullRequestUseCase) MarkReady(ctx context.Context, prID string, strategy entities.ReviewerStrategy) (*entities.PullRequest, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+
+	if err := transitionError(pr.MarkReady()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.assignReviewers(ctx, pr, strategy); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// Закрывает PR без мержа. Ревьюверы остаются в истории PR, но их загрузка считается
+// только по OPEN PR, поэтому они сразу освобождаются
+func (uc *PullRequestUseCase) ClosePR(ctx context.Context, prID string) (*entities.PullRequest, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+
+	if err := transitionError(pr.TransitionTo(entities.StatusClosed)); err != nil {
+		return nil, err
+	}
+	pr.NeedsReviewer = false
+	pr.UpdatedAt = uc.nowPtr()
+
+	if err := uc.prRepo.Update(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// Снова открывает закрытый PR с прежними ревьюверами и перезапускает для них отсчет SLA.
+// Если ревьюверов не было (закрыт черновик), назначает их как MarkReady
+func (uc *PullRequestUseCase) ReopenPR(ctx context.Context, prID string) (*entities.PullRequest, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+
+	if err := transitionError(pr.Reopen()); err != nil {
+		return nil, err
+	}
+
+	if len(pr.AssignedReviewers) == 0 {
+		if err := uc.assignReviewers(ctx, pr, ""); err != nil {
+			return nil, err
+		}
+		return pr, nil
+	}
+
+	pr.UpdatedAt = uc.nowPtr()
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		return uc.prRepo.ResetAssignedAt(ctx, pr.ID, *pr.UpdatedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Среди прежних ревьюверов могли появиться неактивные
+	return uc.prRepo.GetByID(ctx, prID)
+}
+
+// Меняет название и сведения о PR; слитый PR не меняется.
+// Если открытый PR вырос и политика требует больше ревьюверов, недостающие добираются (решение resize)
+func (uc *PullRequestUseCase) UpdatePR(ctx context.Context, prID string, update PullRequestUpdate) (*entities.PullRequest, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+
+	if pr.Status == entities.StatusMerged {
+		return nil, errors.NewDomainError(errors.ErrPRMerged, "cannot update merged PR")
+	}
+
+	if update.Name != nil {
+		if *update.Name == "" {
+			return nil, errors.NewDomainError(errors.ErrInvalidRequest, "pull_request_name must not be empty")
+		}
+		pr.Name = *update.Name
+	}
+
+	metadata := &pr.PullRequestMetadata
+	setIfPresent(&metadata.Description, update.Description)
+	setIfPresent(&metadata.Repository, update.Repository)
+	setIfPresent(&metadata.URL, update.URL)
+	setIfPresent(&metadata.SourceBranch, update.SourceBranch)
+	setIfPresent(&metadata.TargetBranch, update.TargetBranch)
+	setIfPresent(&metadata.LinesAdded, update.LinesAdded)
+	setIfPresent(&metadata.LinesRemoved, update.LinesRemoved)
+
+	if err := metadata.Validate(); err != nil {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, err.Error())
+	}
+
+	// Открытому PR, который вырос до большого, добираем ревьюверов
+	var topUp *selection
+	if pr.Status == entities.StatusOpen {
+		if topUp, err = uc.topUpReviewers(ctx, pr); err != nil {
+			return nil, err
+		}
+	}
+
+	pr.UpdatedAt = uc.nowPtr()
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		if topUp == nil {
+			return nil
+		}
+		if err := uc.prRepo.SaveAssignmentDecision(ctx, topUp.decision(pr.ID, entities.ActionResize)); err != nil {
+			return err
+		}
+		return uc.saveCursors(ctx, topUp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// Добирает ревьюверов открытого PR до числа, которое политика команды PR требует для его размера:
+// из команды PR, затем из резервных команд. Если кандидатов не хватает, PR остается с теми, кто нашелся.
+// Меняет только pr в памяти; возвращает подбор для записи решения или nil, если добирать не нужно
+func (uc *PullRequestUseCase) topUpReviewers(ctx context.Context, pr *entities.PullRequest) (*selection, error) {
+	env, err := uc.newAssignmentEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	team, policy, err := uc.prTeamPolicy(ctx, env.teams, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := policy.ReviewersFor(pr.Size()) - len(pr.AssignedReviewers)
+	if missing <= 0 {
+		return nil, nil
+	}
+
+	strategy, err := uc.resolveStrategy("", policy)
+	if err != nil {
+		return nil, err
+	}
+
+	sel := env.newSelection(strategy, policy, pr.AuthorID, pr.Labels)
+	exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+	selected, err := uc.selectFromTeam(ctx, sel, team, entities.SourceAuthorTeam, exclude, missing, "")
+	if err != nil {
+		return nil, err
+	}
+	pr.AssignedReviewers = append(pr.AssignedReviewers, selected...)
+
+	if missing -= len(selected); missing > 0 && policy.CrossTeamFallback {
+		selected, err = uc.selectFromFallbackTeams(ctx, sel, policy, append(exclude, selected...), missing)
+		if err != nil {
+			return nil, err
+		}
+		pr.AssignedReviewers = append(pr.AssignedReviewers, selected...)
+		pr.FallbackReviewers = append(pr.FallbackReviewers, selected...)
+	}
+
+	return sel, nil
+}
+
+// Назначает ревьюверов PR без ревьюверов по тем же правилам, что и CreatePR
+func (uc *PullRequestUseCase) assignReviewers(ctx context.Context, pr *entities.PullRequest, strategy entities.ReviewerStrategy) error {
+	plan, err := uc.planAssignment(ctx, pr.AuthorID, CreatePROptions{
+		Strategy: strategy,
+		Paths:    pr.Paths,
+		Labels:   pr.Labels,
+		Metadata: pr.PullRequestMetadata,
+		teamName: pr.TeamName,
+	})
+	if err != nil {
+		return err
+	}
+
+	pr.TeamName = plan.TeamName // PR без команды получает текущую основную команду автора
+	pr.AssignedReviewers = plan.Reviewers
+	pr.FallbackReviewers = plan.FallbackReviewers
+	pr.NeedsReviewer = plan.MissingRoleReviewers > 0
+	pr.UpdatedAt = plan.sel.nowPtr()
+
+	// Переход в OPEN, решение о назначении и курсоры записываем в одной транзакции
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		if err := uc.prRepo.SaveAssignmentDecision(ctx, plan.sel.decision(pr.ID, entities.ActionReady)); err != nil {
+			return err
+		}
+		return uc.saveCursors(ctx, plan.sel)
+	})
+}
+
+// Переводит ошибку перехода жизненного цикла в доменную.
+// Для слитого PR сохраняем прежний код PR_MERGED
+func transitionError(err error) error {
+	transition, ok := err.(entities.TransitionError)
+	if !ok {
+		return err
+	}
+
+	if transition.From == entities.StatusMerged {
+		return errors.NewDomainError(errors.ErrPRMerged, "pull request already merged")
+	}
+	return errors.NewDomainError(errors.ErrInvalidTransition, transition.Error())
+}
@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"context"
+
+	"go-project/internal/domain/entities"
+	"go-project/internal/domain/errors"
+)
+
+// Добавляет указанного пользователя в ревьюверы открытого PR сверх подбора и лимита ревью.
+// С pin ревьювер сразу закрепляется; если он уже назначен, меняется только закрепление
+func (uc *PullRequestUseCase) AddReviewer(ctx context.Context, prID, userID string, pin bool) (*entities.PullRequest, error) {
+	pr, err := uc.getOpenPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+	if user.UserID == pr.AuthorID {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "author cannot review own pull request")
+	}
+	if !user.IsActive {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "user is inactive")
+	}
+
+	added := !contains(pr.AssignedReviewers, userID)
+	if added {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, userID)
+	}
+	if pin && !contains(pr.PinnedReviewers, userID) {
+		pr.PinnedReviewers = append(pr.PinnedReviewers, userID)
+	}
+	pr.UpdatedAt = uc.nowPtr()
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		if !added {
+			return nil
+		}
+		return uc.saveManualDecision(ctx, pr, entities.ActionAdd, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// Снимает ревьювера с открытого PR без замены
+func (uc *PullRequestUseCase) RemoveReviewer(ctx context.Context, prID, userID string) (*entities.PullRequest, error) {
+	pr, err := uc.getOpenPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !contains(pr.AssignedReviewers, userID) {
+		return nil, errors.NewDomainError(errors.ErrNotAssigned, "user is not assigned as reviewer")
+	}
+
+	pr.AssignedReviewers = remove(pr.AssignedReviewers, userID)
+	pr.FallbackReviewers = remove(pr.FallbackReviewers, userID)
+	pr.PinnedReviewers = remove(pr.PinnedReviewers, userID)
+	pr.UpdatedAt = uc.nowPtr()
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		return uc.saveManualDecision(ctx, pr, entities.ActionRemove, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Снятый ревьювер мог быть единственным неактивным
+	return uc.prRepo.GetByID(ctx, prID)
+}
+
+// Закрепляет (pinned = true) или открепляет назначенного ревьювера.
+// Закрепленных не трогают автоматическая замена и перераспределение нагрузки
+func (uc *PullRequestUseCase) PinReviewer(ctx context.Context, prID, userID string, pinned bool) (*entities.PullRequest, error) {
+	pr, err := uc.getOpenPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !contains(pr.AssignedReviewers, userID) {
+		return nil, errors.NewDomainError(errors.ErrNotAssigned, "user is not assigned as reviewer")
+	}
+
+	pr.PinnedReviewers = remove(pr.PinnedReviewers, userID)
+	if pinned {
+		pr.PinnedReviewers = append(pr.PinnedReviewers, userID)
+	}
+	pr.UpdatedAt = uc.nowPtr()
+
+	if err := uc.prRepo.Update(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// PR, состав ревьюверов которого можно менять вручную
+func (uc *PullRequestUseCase) getOpenPR(ctx context.Context, prID string) (*entities.PullRequest, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+
+	if pr.Status == entities.StatusMerged {
+		return nil, errors.NewDomainError(errors.ErrPRMerged, "cannot change reviewers on merged PR")
+	}
+	if pr.Status != entities.StatusOpen {
+		return nil, errors.NewDomainError(errors.ErrInvalidTransition, "cannot change reviewers on "+string(pr.Status)+" PR")
+	}
+
+	return pr, nil
+}
+
+// Записывает ручное изменение состава ревьюверов в журнал назначений.
+// Вызывается в одной транзакции с сохранением PR
+func (uc *PullRequestUseCase) saveManualDecision(
+	ctx context.Context,
+	pr *entities.PullRequest,
+	action entities.AssignmentAction,
+	userID string,
+) error {
+	decision := &entities.AssignmentDecision{
+		PullRequestID: pr.ID,
+		Action:        action,
+		Candidates:    []entities.CandidateDecision{},
+		CreatedAt:     *pr.UpdatedAt,
+	}
+
+	if action == entities.ActionRemove {
+		decision.ReplacedUserID = userID
+	} else {
+		decision.Candidates = append(decision.Candidates, entities.CandidateDecision{
+			UserID:   userID,
+			Source:   entities.SourceManual,
+			Selected: true,
+			Rule:     "manual",
+		})
+	}
+
+	return uc.prRepo.SaveAssignmentDecision(ctx, decision)
+}
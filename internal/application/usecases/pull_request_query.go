@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"go-project/internal/domain/entities"
+	"go-project/internal/domain/errors"
+)
+
+// PR по ID со всеми ревьюверами и метками
+func (uc *PullRequestUseCase) GetPR(ctx context.Context, prID string) (*entities.PullRequest, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+	return pr, nil
+}
+
+// Страница PR по фильтру и курсор следующей ("" - страница последняя).
+// Курсор хранит (created_at, id) последнего PR, поэтому новые PR не сдвигают уже выданные страницы,
+// и порядок сортировки: курсор с другим порядком отклоняется
+func (uc *PullRequestUseCase) ListPRs(
+	ctx context.Context,
+	filter entities.PullRequestListFilter,
+	cursor string,
+) ([]*entities.PullRequest, string, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, "", errors.NewDomainError(errors.ErrInvalidRequest, "unknown status: "+string(filter.Status))
+	}
+	if !validPeriod(filter.CreatedFrom, filter.CreatedTo) {
+		return nil, "", errors.NewDomainError(errors.ErrInvalidRequest, "created_from must be before created_to")
+	}
+	if !validPeriod(filter.MergedFrom, filter.MergedTo) {
+		return nil, "", errors.NewDomainError(errors.ErrInvalidRequest, "merged_from must be before merged_to")
+	}
+
+	limit, err := pageSize(filter.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if cursor != "" {
+		filter.After = &entities.PullRequestPageKey{}
+		if err := decodeCursor(cursor, filter.After); err != nil {
+			return nil, "", err
+		}
+		if filter.After.Descending != filter.Descending {
+			return nil, "", errors.NewDomainError(errors.ErrInvalidRequest, "cursor was issued for a different order")
+		}
+	}
+
+	filter.Limit = limit + 1
+	prs, err := uc.prRepo.List(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Лишняя запись означает, что есть следующая страница
+	if len(prs) <= limit {
+		return prs, "", nil
+	}
+	prs = prs[:limit]
+	last := prs[limit-1]
+	return prs, encodeCursor(entities.PullRequestPageKey{
+		CreatedAt:  *last.CreatedAt,
+		ID:         last.ID,
+		Descending: filter.Descending,
+	}), nil
+}
+
+// Период [from, to) не пуст или открыт с одной из сторон
+func validPeriod(from, to *time.Time) bool {
+	return from == nil || to == nil || from.Before(*to)
+}
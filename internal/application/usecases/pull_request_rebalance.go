@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"go-project/internal/domain/entities"
+	"go-project/internal/domain/errors"
+)
+
+// Переносит неначатые незакрепленные ревью с самых загруженных участников команды на наименее загруженных,
+// пока разница в загрузке между ними больше одного ревью. Получатель должен подходить для PR
+// по тем же правилам, что и при назначении: активен, не в отсутствии, с запасом по лимиту, не автор и не назначен.
+// Вызывающий оборачивает операцию в транзакцию
+func (uc *PullRequestUseCase) rebalanceTeam(ctx context.Context, teamName string) ([]*entities.ReviewMove, error) {
+	env, err := uc.newAssignmentEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := env.teams.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+
+	policy, err := env.teams.GetPolicy(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs := make([]string, 0, len(team.Members))
+	for _, member := range team.Members {
+		memberIDs = append(memberIDs, member.UserID)
+	}
+
+	pending, err := uc.prRepo.GetPendingReviews(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	moves := []*entities.ReviewMove{}
+	prs := make(map[string]*entities.PullRequest)
+
+	// Каждый перенос уменьшает разброс загрузки, поэтому цикл конечен
+	for moved := true; moved; {
+		moved = false
+
+		// Загрузка участников меняется после каждого переноса (в кэше команд)
+		members := slices.Clone(team.Members)
+		slices.SortStableFunc(members, func(a, b *entities.User) int {
+			return cmp.Or(cmp.Compare(a.OpenReviews, b.OpenReviews), cmp.Compare(a.UserID, b.UserID))
+		})
+
+		for i := len(members) - 1; i >= 0 && !moved; i-- {
+			source := members[i]
+
+			for j, review := range pending {
+				if review.ReviewerID != source.UserID {
+					continue
+				}
+
+				pr, ok := prs[review.PullRequestID]
+				if !ok {
+					if pr, err = uc.prRepo.GetByID(ctx, review.PullRequestID); err != nil {
+						return nil, err
+					}
+					prs[pr.ID] = pr
+				}
+
+				authorTeam, authorPolicy, err := uc.prTeamPolicy(ctx, env.teams, pr)
+				if err != nil {
+					return nil, err
+				}
+
+				sel := env.newSelection(entities.StrategyLeastOpenReviews, policy, pr.AuthorID, pr.Labels)
+				var target *entities.User
+				for _, member := range members[:i] {
+					if member.OpenReviews+1 < source.OpenReviews && sel.exclusionReason(member, pr.AssignedReviewers) == "" &&
+						keepsRoleRequirement(authorTeam, authorPolicy, pr.AssignedReviewers, source.UserID, member.UserID) {
+						target = member
+						break
+					}
+				}
+				if target == nil {
+					continue
+				}
+
+				pr.AssignedReviewers[slices.Index(pr.AssignedReviewers, source.UserID)] = target.UserID
+				if index := slices.Index(pr.FallbackReviewers, source.UserID); index >= 0 {
+					pr.FallbackReviewers[index] = target.UserID // получатель из той же резервной команды
+				}
+				pr.UpdatedAt = env.nowPtr()
+
+				if err := uc.prRepo.Update(ctx, pr); err != nil {
+					return nil, err
+				}
+
+				sel.recordSelected(target.UserID, teamName, entities.SourceReviewerTeam, 0, "rebalance")
+				decision := sel.decision(pr.ID, entities.ActionRebalance)
+				decision.ReplacedUserID = source.UserID
+				if err := uc.prRepo.SaveAssignmentDecision(ctx, decision); err != nil {
+					return nil, err
+				}
+
+				env.teams.removeOpenReview(source.UserID)
+				env.teams.addOpenReview(target.UserID)
+				pending = slices.Delete(pending, j, j+1)
+
+				moves = append(moves, &entities.ReviewMove{
+					PullRequestID: pr.ID,
+					TeamName:      teamName,
+					FromUserID:    source.UserID,
+					ToUserID:      target.UserID,
+				})
+				moved = true
+				break
+			}
+		}
+	}
+
+	return moves, nil
+}
+
+// Команда PR и ее политика; PR без команды разрешается так же, как в teamCache.GetByPR
+func (uc *PullRequestUseCase) prTeamPolicy(
+	ctx context.Context,
+	teams *teamCache,
+	pr *entities.PullRequest,
+) (*entities.Team, *entities.TeamPolicy, error) {
+	team, err := teams.GetByPR(ctx, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policy, err := teams.GetPolicy(ctx, team.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return team, policy, nil
+}
+
+// Замена sourceID на targetID не уменьшает число ревьюверов с ролью, которую требует политика команды PR,
+// если их и так не больше минимума
+func keepsRoleRequirement(team *entities.Team, policy *entities.TeamPolicy, reviewers []string, sourceID, targetID string) bool {
+	if policy.MinRoleReviewers == 0 {
+		return true
+	}
+
+	after := slices.Clone(reviewers)
+	after[slices.Index(after, sourceID)] = targetID
+	return team.CountWithRole(after, policy.RequiredRole) >=
+		min(policy.MinRoleReviewers, team.CountWithRole(reviewers, policy.RequiredRole))
+}
@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-project/internal/domain/entities"
+	"go-project/internal/domain/errors"
+)
+
+// Сохраняет решение назначенного ревьювера по открытому PR
+func (uc *PullRequestUseCase) SubmitReview(
+	ctx context.Context,
+	prID, reviewerID string,
+	state entities.ReviewState,
+	comment string,
+) (*entities.Review, error) {
+	if !state.IsValid() {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "unknown review state: "+string(state))
+	}
+	if state == entities.ReviewDismissed {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "use dismissReview to dismiss a review")
+	}
+
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+
+	if pr.Status == entities.StatusMerged {
+		return nil, errors.NewDomainError(errors.ErrPRMerged, "cannot review merged PR")
+	}
+	if pr.Status != entities.StatusOpen {
+		return nil, errors.NewDomainError(errors.ErrInvalidTransition, "cannot review "+string(pr.Status)+" PR")
+	}
+
+	if !contains(pr.AssignedReviewers, reviewerID) {
+		return nil, errors.NewDomainError(errors.ErrNotAssigned, "user is not assigned as reviewer")
+	}
+
+	review := &entities.Review{
+		PullRequestID: prID,
+		ReviewerID:    reviewerID,
+		State:         state,
+		Comment:       comment,
+		CreatedAt:     uc.clock.Now(),
+	}
+	if err := uc.prRepo.AddReview(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// Отменяет действующий запрос изменений ревьювера, в том числе уже снятого с PR.
+// Отмена сохраняется в истории ревью вместе с тем, кто ее сделал, и причиной
+func (uc *PullRequestUseCase) DismissReview(
+	ctx context.Context,
+	prID, reviewerID, dismissedBy, reason string,
+) (*entities.Review, error) {
+	if dismissedBy == "" || strings.TrimSpace(reason) == "" {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "dismissed_by and reason are required")
+	}
+	if _, err := uc.userRepo.GetByID(ctx, dismissedBy); err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, "user not found")
+	}
+
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+	if pr.Status == entities.StatusMerged {
+		return nil, errors.NewDomainError(errors.ErrPRMerged, "cannot dismiss review on merged PR")
+	}
+
+	reviews, err := uc.prRepo.GetReviews(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	status := entities.SummarizeReviews(reviews, pr.AssignedReviewers)
+	if !contains(status.ChangesRequestedBy, reviewerID) {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "reviewer has no pending change request")
+	}
+
+	review := &entities.Review{
+		PullRequestID: prID,
+		ReviewerID:    reviewerID,
+		State:         entities.ReviewDismissed,
+		Comment:       reason,
+		DismissedBy:   dismissedBy,
+		CreatedAt:     uc.clock.Now(),
+	}
+	if err := uc.prRepo.AddReview(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// История ревью PR и действующие решения ревьюверов
+func (uc *PullRequestUseCase) GetReviews(ctx context.Context, prID string) ([]*entities.Review, entities.ReviewStatus, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, entities.ReviewStatus{}, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+
+	reviews, err := uc.prRepo.GetReviews(ctx, prID)
+	if err != nil {
+		return nil, entities.ReviewStatus{}, err
+	}
+
+	return reviews, entities.SummarizeReviews(reviews, pr.AssignedReviewers), nil
+}
+
+// Мерж разрешен, если набрано min_approvals команды PR и нет неотмененных запросов изменений,
+// включая запросы ревьюверов, которых уже сняли с PR
+func (uc *PullRequestUseCase) checkMergeAllowed(ctx context.Context, pr *entities.PullRequest) error {
+	_, policy, err := uc.prTeamPolicy(ctx, newTeamCache(uc.teamRepo), pr)
+	if err != nil {
+		return err
+	}
+
+	reviews, err := uc.prRepo.GetReviews(ctx, pr.ID)
+	if err != nil {
+		return err
+	}
+	status := entities.SummarizeReviews(reviews, pr.AssignedReviewers)
+
+	if len(status.ChangesRequestedBy) > 0 {
+		return errors.NewDomainError(errors.ErrMergeBlocked,
+			"changes requested by "+strings.Join(status.ChangesRequestedBy, ", "))
+	}
+	if len(status.ApprovedBy) < policy.MinApprovals {
+		return errors.NewDomainError(errors.ErrMergeBlocked,
+			fmt.Sprintf("pull request needs %d approvals, has %d", policy.MinApprovals, len(status.ApprovedBy)))
+	}
+
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"go-project/internal/domain/entities"
 	"go-project/internal/domain/errors"
 	"go-project/internal/domain/repositories"
+	"slices"
+	"sort"
 	"time"
 )
 
@@ -12,29 +14,283 @@ type PullRequestUseCase struct {
 	prRepo   repositories.PullRequestRepository
 	teamRepo repositories.TeamRepository
 	userRepo repositories.UserRepository
+	ruleRepo repositories.OwnershipRuleRepository
+
+	txManager repositories.TxManager
+
+	defaultStrategy entities.ReviewerStrategy // стратегия выбора ревьюверов для всего развертывания
+	clock           Clock
+}
+
+// CreatePROptions - необязательные параметры создания PR
+type CreatePROptions struct {
+	Strategy entities.ReviewerStrategy // переопределяет стратегию по умолчанию для этого запроса
+	Paths    []string                  // измененные файлы: их владельцы назначаются в первую очередь
+	Labels   []string                  // метки PR: предпочитаем ревьюверов с подходящими навыками
+	Draft    bool                      // создать черновик: ревьюверы назначаются в MarkReady
+
+	teamName string // команда уже созданного PR ("" - основная команда автора)
+
+	// Сведения о PR; по его размеру политика команды может назначить больше ревьюверов
+	Metadata entities.PullRequestMetadata
+}
+
+// PullRequestUpdate - изменяемые поля PR (nil - оставить как есть)
+type PullRequestUpdate struct {
+	Name         *string
+	Description  *string
+	Repository   *string
+	URL          *string
+	SourceBranch *string
+	TargetBranch *string
+	LinesAdded   *int
+	LinesRemoved *int
+}
+
+// AssignmentPlan - подобранные ревьюверы нового PR и решения по всем кандидатам
+type AssignmentPlan struct {
+	TeamName             string
+	Strategy             entities.ReviewerStrategy
+	RequiredReviewers    int
+	MissingRole          entities.TeamRole // роль, ревьюверов с которой не хватило (MissingRoleReviewers > 0)
+	MissingRoleReviewers int
+	Labels               []string
+	Reviewers            []string
+	FallbackReviewers    []string
+	Candidates           []entities.CandidateDecision // в порядке рассмотрения, ранг заполнен у ранжированных стратегией
+
+	sel *selection
+}
+
+// ReviewerReplacement - замена ревьювера на PR (NewReviewerID пуст, если замены не нашлось)
+type ReviewerReplacement struct {
+	PullRequestID string
+	OldReviewerID string
+	NewReviewerID string
+}
+
+// ReassignmentSummary - итог массовой замены ревьюверов
+type ReassignmentSummary struct {
+	Reassigned   []ReviewerReplacement
+	Unassignable []ReviewerReplacement // ревьювер остается назначенным
+	Untouched    []string              // PR не в статусе OPEN или другой команды, где пользователи были ревьюверами
+}
+
+// Добавляет итог другой массовой замены; PR, уже перечисленные в Untouched, не повторяются
+func (s *ReassignmentSummary) merge(other *ReassignmentSummary) {
+	s.Reassigned = append(s.Reassigned, other.Reassigned...)
+	s.Unassignable = append(s.Unassignable, other.Unassignable...)
+	for _, prID := range other.Untouched {
+		if !contains(s.Untouched, prID) {
+			s.Untouched = append(s.Untouched, prID)
+		}
+	}
+}
+
+// Общие данные операции назначения: момент времени, отсутствующие пользователи и прочитанные команды
+type assignmentEnv struct {
+	now         time.Time
+	outOfOffice []string // пользователи в периоде отсутствия на момент назначения
+	teams       *teamCache
+}
+
+func (uc *PullRequestUseCase) newAssignmentEnv(ctx context.Context) (*assignmentEnv, error) {
+	now := uc.clock.Now()
+	outOfOffice, err := uc.userRepo.GetOutOfOfficeUserIDs(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &assignmentEnv{
+		now:         now,
+		outOfOffice: outOfOffice,
+		teams:       newTeamCache(uc.teamRepo),
+	}, nil
+}
+
+func (env *assignmentEnv) nowPtr() *time.Time {
+	now := env.now
+	return &now
+}
+
+// Параметры одного подбора ревьюверов
+type selection struct {
+	*assignmentEnv
+
+	strategy           entities.ReviewerStrategy
+	authorID           string
+	labels             []string
+	preferWorkingHours bool              // сначала кандидаты, у которых сейчас рабочее время
+	skillOnly          bool              // подбор места под навык: только кандидаты с навыками по меткам PR
+	skillMatched       bool              // среди выбранных уже есть ревьювер с навыком по меткам PR
+	cursors            map[string]string // курсоры round-robin, которые нужно сохранить после назначения
+
+	missingRole          entities.TeamRole // требование политики к роли, которое не удалось выполнить
+	missingRoleReviewers int
+
+	candidates []entities.CandidateDecision // решения по всем рассмотренным кандидатам
+}
+
+func (env *assignmentEnv) newSelection(
+	strategy entities.ReviewerStrategy,
+	policy *entities.TeamPolicy,
+	authorID string,
+	labels []string,
+) *selection {
+	return &selection{
+		assignmentEnv:      env,
+		strategy:           strategy,
+		authorID:           authorID,
+		labels:             labels,
+		preferWorkingHours: policy.PreferWorkingHours,
+		cursors:            make(map[string]string),
+		candidates:         []entities.CandidateDecision{},
+	}
+}
+
+// Решение о назначении по итогам подбора
+func (sel *selection) decision(prID string, action entities.AssignmentAction) *entities.AssignmentDecision {
+	return &entities.AssignmentDecision{
+		PullRequestID:        prID,
+		Action:               action,
+		Strategy:             sel.strategy,
+		Candidates:           sel.candidates,
+		CreatedAt:            sel.now,
+		MissingRole:          sel.missingRole,
+		MissingRoleReviewers: sel.missingRoleReviewers,
+	}
+}
+
+func (sel *selection) recordSelected(userID, teamName string, source entities.CandidateSource, rank int, rule string) {
+	sel.candidates = append(sel.candidates, entities.CandidateDecision{
+		UserID:   userID,
+		TeamName: teamName,
+		Source:   source,
+		Rank:     rank,
+		Selected: true,
+		Rule:     rule,
+	})
+}
+
+func (sel *selection) recordExcluded(userID, teamName string, source entities.CandidateSource, rank int, reason entities.ExclusionReason) {
+	sel.candidates = append(sel.candidates, entities.CandidateDecision{
+		UserID:   userID,
+		TeamName: teamName,
+		Source:   source,
+		Rank:     rank,
+		Excluded: reason,
+	})
 }
 
 func NewPullRequestUseCase(
 	prRepo repositories.PullRequestRepository,
 	teamRepo repositories.TeamRepository,
 	userRepo repositories.UserRepository,
+	ruleRepo repositories.OwnershipRuleRepository,
+	txManager repositories.TxManager,
+	defaultStrategy entities.ReviewerStrategy,
+	clock Clock,
 ) *PullRequestUseCase {
 	return &PullRequestUseCase{
-		prRepo:   prRepo,
-		teamRepo: teamRepo,
-		userRepo: userRepo,
+		prRepo:          prRepo,
+		teamRepo:        teamRepo,
+		userRepo:        userRepo,
+		ruleRepo:        ruleRepo,
+		txManager:       txManager,
+		defaultStrategy: defaultStrategy,
+		clock:           clock,
 	}
 }
 
-func (uc *PullRequestUseCase) CreatePR(ctx context.Context, authorID, prID, prName string) (*entities.PullRequest, error) {
+func (uc *PullRequestUseCase) CreatePR(ctx context.Context, authorID, prID, prName string, opts CreatePROptions) (*entities.PullRequest, error) {
 	// Проверяем существование PR
 	existing, _ := uc.prRepo.GetByID(ctx, prID)
 	if existing != nil {
 		return nil, errors.NewDomainError(errors.ErrPRExists, "pull request already exists")
 	}
 
-	// Получаем команду автора
-	team, err := uc.teamRepo.GetByUserID(ctx, authorID)
+	if err := opts.Metadata.Validate(); err != nil {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, err.Error())
+	}
+
+	pr := &entities.PullRequest{
+		ID:                prID,
+		Name:              prName,
+		AuthorID:          authorID,
+		AssignedReviewers: []string{},
+		Labels:            entities.NormalizeTags(opts.Labels),
+		Paths:             opts.Paths,
+		Status:            entities.StatusOpen,
+		CreatedAt:         uc.nowPtr(),
+
+		PullRequestMetadata: opts.Metadata,
+	}
+
+	// Черновик создаем без ревьюверов, автор только должен состоять в команде
+	if opts.Draft {
+		team, err := uc.teamRepo.GetByUserID(ctx, authorID)
+		if err != nil {
+			return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+		}
+
+		pr.TeamName = team.Name
+		pr.Status = entities.StatusDraft
+		if err := uc.prRepo.Create(ctx, pr); err != nil {
+			return nil, err
+		}
+		return pr, nil
+	}
+
+	plan, err := uc.planAssignment(ctx, authorID, opts)
+	if err != nil {
+		return nil, err
+	}
+	pr.TeamName = plan.TeamName
+	pr.AssignedReviewers = plan.Reviewers
+	pr.FallbackReviewers = plan.FallbackReviewers
+	pr.NeedsReviewer = plan.MissingRoleReviewers > 0
+
+	// PR, решение о назначении и курсоры записываем в одной транзакции, чтобы журнал назначений был полным
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.prRepo.Create(ctx, pr); err != nil {
+			return err
+		}
+		if err := uc.prRepo.SaveAssignmentDecision(ctx, plan.sel.decision(prID, entities.ActionCreate)); err != nil {
+			return err
+		}
+		return uc.saveCursors(ctx, plan.sel)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// Показывает, кого назначил бы CreatePR с теми же параметрами, ничего не записывая.
+// Для стратегии random результат может отличаться от фактического назначения
+func (uc *PullRequestUseCase) PreviewAssignment(ctx context.Context, authorID string, opts CreatePROptions) (*AssignmentPlan, error) {
+	if err := opts.Metadata.Validate(); err != nil {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, err.Error())
+	}
+	return uc.planAssignment(ctx, authorID, opts)
+}
+
+// Подбирает ревьюверов для нового PR автора: владельцы путей, затем команда автора, затем резервные команды.
+// Только читает данные; курсоры round-robin и решения сохраняет вызывающий
+func (uc *PullRequestUseCase) planAssignment(ctx context.Context, authorID string, opts CreatePROptions) (*AssignmentPlan, error) {
+	env, err := uc.newAssignmentEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Получаем команду автора: для уже созданного PR - сохраненную в нем
+	var team *entities.Team
+	if opts.teamName != "" {
+		team, err = env.teams.GetByName(ctx, opts.teamName)
+	} else {
+		team, err = env.teams.GetByUserID(ctx, authorID)
+	}
 	if err != nil {
 		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
 	}
@@ -44,41 +300,101 @@ func (uc *PullRequestUseCase) CreatePR(ctx context.Context, authorID, prID, prNa
 		return nil, errors.NewDomainError(errors.ErrNotFound, "resource not found")
 	}
 
-	// Собираем активных ревьюверов (исключая автора)
-	var reviewers []string
-	for _, member := range team.Members {
-		// Пропускаем автора
-		if member.UserID == authorID {
-			continue
-		}
+	// Политику читаем при каждом назначении, чтобы изменения применялись сразу
+	policy, err := env.teams.GetPolicy(ctx, team.Name)
+	if err != nil {
+		return nil, err
+	}
 
-		// Собираем активных ревьюверов
-		if member.IsActive {
-			reviewers = append(reviewers, member.UserID)
-			if len(reviewers) >= 2 {
-				break // набрали достаточно ревьюверов
-			}
-		}
+	strategy, err := uc.resolveStrategy(opts.Strategy, policy)
+	if err != nil {
+		return nil, err
 	}
 
-	pr := &entities.PullRequest{
-		ID:                prID,
-		Name:              prName,
-		AuthorID:          authorID,
-		AssignedReviewers: reviewers,
-		Status:            entities.StatusOpen,
-		CreatedAt:         nowPtr(),
+	labels := entities.NormalizeTags(opts.Labels)
+	sel := env.newSelection(strategy, policy, authorID, labels)
+	exclude := []string{authorID}
+
+	// Большим PR политика может назначать больше ревьюверов
+	required := policy.ReviewersFor(opts.Metadata.Size())
+
+	// Одно место оставляем ревьюверу с навыком по меткам PR, если такой найдется
+	skillSeat := 0
+	if len(labels) > 0 && required > policy.MinRoleReviewers {
+		skillSeat = 1
 	}
 
-	if err := uc.prRepo.Create(ctx, pr); err != nil {
+	// Сначала назначаем владельцев измененных путей; места под обязательную роль и навык им не отдаем
+	reviewers, err := uc.selectOwners(ctx, sel, opts.Paths, exclude, required-policy.MinRoleReviewers-skillSeat)
+	if err != nil {
 		return nil, err
 	}
+	exclude = append(exclude, reviewers...)
+
+	// Затем недостающих ревьюверов с ролью, которую требует политика. Если их не хватает, PR создается
+	// с теми, кто нашелся, и помечается needs_reviewer, а нехватка записывается в решение
+	if missing := policy.MinRoleReviewers - team.CountWithRole(reviewers, policy.RequiredRole); missing > 0 {
+		roleReviewers, err := uc.selectFromTeam(ctx, sel, team, entities.SourceAuthorTeam, exclude, missing, policy.RequiredRole)
+		if err != nil {
+			return nil, err
+		}
+		if len(roleReviewers) < missing {
+			sel.missingRole = policy.RequiredRole
+			sel.missingRoleReviewers = missing - len(roleReviewers)
+		}
+		reviewers = append(reviewers, roleReviewers...)
+		exclude = append(exclude, roleReviewers...)
+	}
 
-	for _, reviewer := range reviewers {
-		uc.userRepo.SetActive(ctx, reviewer, false)
+	// Если среди выбранных нет ревьювера с навыком по меткам, ищем его в команде автора, затем в резервных.
+	// Это предпочтение: без подходящих кандидатов место заполняется как обычно
+	var fallbackReviewers []string
+	if skillSeat > 0 && !sel.skillMatched && len(reviewers) < required {
+		sel.skillOnly = true
+		skilled, err := uc.selectFromTeam(ctx, sel, team, entities.SourceAuthorTeam, exclude, 1, "")
+		if err == nil && len(skilled) == 0 && policy.CrossTeamFallback {
+			skilled, err = uc.selectFromFallbackTeams(ctx, sel, policy, exclude, 1)
+			fallbackReviewers = skilled
+		}
+		sel.skillOnly = false
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, skilled...)
+		exclude = append(exclude, skilled...)
 	}
 
-	return pr, nil
+	// Остальные места заполняем доступными кандидатами с запасом по лимиту ревью из команды автора
+	teamReviewers, err := uc.selectFromTeam(ctx, sel, team, entities.SourceAuthorTeam, exclude,
+		required-len(reviewers), "")
+	if err != nil {
+		return nil, err
+	}
+	reviewers = append(reviewers, teamReviewers...)
+
+	// Недостающих ревьюверов добираем из резервных команд
+	if missing := required - len(reviewers); missing > 0 && policy.CrossTeamFallback {
+		exclude = append(exclude, reviewers...)
+		selected, err := uc.selectFromFallbackTeams(ctx, sel, policy, exclude, missing)
+		if err != nil {
+			return nil, err
+		}
+		fallbackReviewers = append(fallbackReviewers, selected...)
+		reviewers = append(reviewers, selected...)
+	}
+
+	return &AssignmentPlan{
+		TeamName:             team.Name,
+		Strategy:             strategy,
+		RequiredReviewers:    required,
+		MissingRole:          sel.missingRole,
+		MissingRoleReviewers: sel.missingRoleReviewers,
+		Labels:               labels,
+		Reviewers:            reviewers,
+		FallbackReviewers:    fallbackReviewers,
+		Candidates:           sel.candidates,
+		sel:                  sel,
+	}, nil
 }
 
 func (uc *PullRequestUseCase) MergePR(ctx context.Context, prID string) (*entities.PullRequest, error) {
@@ -91,26 +407,27 @@ func (uc *PullRequestUseCase) MergePR(ctx context.Context, prID string) (*entiti
 		return nil, errors.NewDomainError(errors.ErrNotFound, "resource not found")
 	}
 
-	if pr.Status == entities.StatusMerged {
-		return nil, errors.NewDomainError(errors.ErrPRMerged, "pull request already merged")
+	if err := transitionError(pr.TransitionTo(entities.StatusMerged)); err != nil {
+		return nil, err
 	}
 
-	pr.Status = entities.StatusMerged
-	pr.MergedAt = nowPtr()
-
-	if err := uc.prRepo.Update(ctx, pr); err != nil {
+	if err := uc.checkMergeAllowed(ctx, pr); err != nil {
 		return nil, err
 	}
 
-	// Активируем ревьюверов после мержа PR (чтобы была возможность поставить их на новый PR)
-	for _, reviewerID := range pr.AssignedReviewers {
-		uc.userRepo.SetActive(ctx, reviewerID, true)
+	pr.MergedAt = uc.nowPtr()
+	pr.UpdatedAt = pr.MergedAt
+	pr.NeedsReviewer = false // флаг имеет смысл только для открытых PR
+
+	// Доступность ревьюверов не трогаем: их загрузка считается по OPEN PR и уменьшится сама
+	if err := uc.prRepo.Update(ctx, pr); err != nil {
+		return nil, err
 	}
 
 	return pr, nil
 }
 
-func (uc *PullRequestUseCase) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*entities.PullRequest, string, error) {
+func (uc *PullRequestUseCase) ReassignReviewer(ctx context.Context, prID, oldUserID string, strategy entities.ReviewerStrategy) (*entities.PullRequest, string, error) {
 	pr, err := uc.prRepo.GetByID(ctx, prID)
 	if err != nil {
 		return nil, "", errors.NewDomainError(errors.ErrNotFound, err.Error())
@@ -123,57 +440,208 @@ func (uc *PullRequestUseCase) ReassignReviewer(ctx context.Context, prID, oldUse
 	if pr.Status == entities.StatusMerged {
 		return nil, "", errors.NewDomainError(errors.ErrPRMerged, "cannot reassign on merged PR")
 	}
-
-	// Находим старого ревьювера в списке
-	found := false
-	oldReviewerIndex := -1
-	for i, reviewer := range pr.AssignedReviewers {
-		if reviewer == oldUserID {
-			found = true
-			oldReviewerIndex = i
-			break
-		}
+	if pr.Status != entities.StatusOpen {
+		return nil, "", errors.NewDomainError(errors.ErrInvalidTransition, "cannot reassign on "+string(pr.Status)+" PR")
 	}
 
-	if !found {
+	if !contains(pr.AssignedReviewers, oldUserID) {
 		return nil, "", errors.NewDomainError(errors.ErrNotAssigned, "user is not assigned as reviewer")
 	}
 
-	// Получаем команду для поиска замены
-	team, err := uc.teamRepo.GetByUserID(ctx, oldUserID)
+	env, err := uc.newAssignmentEnv(ctx)
 	if err != nil {
-		return nil, "", errors.NewDomainError(errors.ErrNotFound, err.Error())
+		return nil, "", err
 	}
 
-	if team == nil {
-		return nil, "", errors.NewDomainError(errors.ErrNotFound, "resource not found")
+	newReviewer, sel, err := uc.replaceReviewer(ctx, env, pr, oldUserID, strategy)
+	if err != nil {
+		return nil, "", err
 	}
 
-	// Ищем активного ревьювера (исключая уже назначенных и автора)
-	var newReviewer string
-	for _, member := range team.Members {
-		if member.IsActive &&
-			member.UserID != pr.AuthorID &&
-			member.UserID != oldUserID && // исключаем старого ревьювера
-			!contains(pr.AssignedReviewers, member.UserID) {
-			newReviewer = member.UserID
-			break
+	// Неудачную попытку тоже записываем в журнал: в решении видно, почему исключен каждый кандидат
+	decision := sel.decision(prID, entities.ActionReassign)
+	decision.ReplacedUserID = oldUserID
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if newReviewer == "" {
+			return uc.prRepo.SaveAssignmentDecision(ctx, decision)
+		}
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		if err := uc.prRepo.SaveAssignmentDecision(ctx, decision); err != nil {
+			return err
 		}
+		return uc.saveCursors(ctx, sel)
+	})
+	if err != nil {
+		return nil, "", err
 	}
 
 	if newReviewer == "" {
-		return nil, "", errors.NewDomainError(errors.ErrNoCandidate, "no active reviewers available")
+		return nil, "", errors.NewDomainError(errors.ErrNoCandidate, "no active reviewers below review capacity")
 	}
 
-	pr.AssignedReviewers[oldReviewerIndex] = newReviewer
-
-	if err := uc.prRepo.Update(ctx, pr); err != nil {
+	// Перечитываем PR, чтобы флаг needs_reviewer учитывал замену
+	pr, err = uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
 		return nil, "", err
 	}
 
 	return pr, newReviewer, nil
 }
 
+// Заменяет oldUserID в pr кандидатом из команды PR, а если там никого нет - из резервных команд.
+// Меняет только pr в памяти; если замены нет, возвращает пустую строку и pr не меняется
+func (uc *PullRequestUseCase) replaceReviewer(
+	ctx context.Context,
+	env *assignmentEnv,
+	pr *entities.PullRequest,
+	oldUserID string,
+	strategy entities.ReviewerStrategy,
+) (string, *selection, error) {
+	// Замену ищем в команде PR (старый ревьювер мог прийти из резервной команды)
+	team, policy, err := uc.prTeamPolicy(ctx, env.teams, pr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	strategy, err = uc.resolveStrategy(strategy, policy)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Если без старого ревьювера не хватает ревьюверов с требуемой ролью, замена должна ее иметь
+	var role entities.TeamRole
+	if team.CountWithRole(remove(pr.AssignedReviewers, oldUserID), policy.RequiredRole) < policy.MinRoleReviewers {
+		role = policy.RequiredRole
+	}
+
+	// Собираем доступных кандидатов с запасом по лимиту ревью (исключая уже назначенных и автора;
+	// старый ревьювер уже есть в списке назначенных)
+	sel := env.newSelection(strategy, policy, pr.AuthorID, pr.Labels)
+	exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+	selected, err := uc.selectFromTeam(ctx, sel, team, entities.SourceAuthorTeam, exclude, 1, role)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Роль учитывается только в команде автора, поэтому резервные команды тогда не подходят
+	fromFallback := false
+	if len(selected) == 0 && policy.CrossTeamFallback && role == "" {
+		selected, err = uc.selectFromFallbackTeams(ctx, sel, policy, exclude, 1)
+		if err != nil {
+			return "", nil, err
+		}
+		fromFallback = true
+	}
+
+	if len(selected) == 0 {
+		return "", sel, nil
+	}
+	newReviewer := selected[0]
+
+	pr.AssignedReviewers[slices.Index(pr.AssignedReviewers, oldUserID)] = newReviewer
+	pr.FallbackReviewers = remove(pr.FallbackReviewers, oldUserID)
+	pr.PinnedReviewers = remove(pr.PinnedReviewers, oldUserID) // закрепление не переходит к замене
+	if fromFallback {
+		pr.FallbackReviewers = append(pr.FallbackReviewers, newReviewer)
+	}
+	pr.UpdatedAt = env.nowPtr()
+	env.teams.addOpenReview(newReviewer)
+
+	return newReviewer, sel, nil
+}
+
+// Заменяет userIDs во всех OPEN PR, где они ревьюверы, по тем же правилам, что и ReassignReviewer.
+// С непустым teamName заменяет только в PR этой команды.
+// Закрепленные ревьюверы остаются назначенными и попадают в Unassignable.
+// Пользователи к этому моменту уже должны быть недоступны; вызывающий оборачивает операцию в транзакцию
+func (uc *PullRequestUseCase) reassignOpenReviews(ctx context.Context, userIDs []string, teamName string) (*ReassignmentSummary, error) {
+	summary := &ReassignmentSummary{
+		Reassigned:   []ReviewerReplacement{},
+		Unassignable: []ReviewerReplacement{},
+		Untouched:    []string{},
+	}
+
+	prs, err := uc.prRepo.GetByReviewers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Команды читаем один раз на всю операцию, загрузку новых ревьюверов учитываем в памяти
+	env, err := uc.newAssignmentEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pr := range prs {
+		if pr.Status != entities.StatusOpen {
+			summary.Untouched = append(summary.Untouched, pr.ID)
+			continue
+		}
+
+		if teamName != "" {
+			team, err := env.teams.GetByPR(ctx, pr)
+			if err != nil {
+				return nil, err
+			}
+			if team.Name != teamName {
+				summary.Untouched = append(summary.Untouched, pr.ID)
+				continue
+			}
+		}
+
+		var decisions []*entities.AssignmentDecision
+		for _, oldUserID := range slices.Clone(pr.AssignedReviewers) {
+			if !contains(userIDs, oldUserID) {
+				continue
+			}
+
+			// Закрепленных вручную не заменяем автоматически
+			if contains(pr.PinnedReviewers, oldUserID) {
+				summary.Unassignable = append(summary.Unassignable,
+					ReviewerReplacement{PullRequestID: pr.ID, OldReviewerID: oldUserID})
+				continue
+			}
+
+			newReviewer, sel, err := uc.replaceReviewer(ctx, env, pr, oldUserID, "")
+			if err != nil {
+				return nil, err
+			}
+
+			replacement := ReviewerReplacement{PullRequestID: pr.ID, OldReviewerID: oldUserID, NewReviewerID: newReviewer}
+			if newReviewer == "" {
+				summary.Unassignable = append(summary.Unassignable, replacement)
+				continue
+			}
+			summary.Reassigned = append(summary.Reassigned, replacement)
+
+			decision := sel.decision(pr.ID, entities.ActionReassign)
+			decision.ReplacedUserID = oldUserID
+			decisions = append(decisions, decision)
+
+			if err := uc.saveCursors(ctx, sel); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(decisions) == 0 {
+			continue
+		}
+
+		if err := uc.prRepo.Update(ctx, pr); err != nil {
+			return nil, err
+		}
+		for _, decision := range decisions {
+			if err := uc.prRepo.SaveAssignmentDecision(ctx, decision); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return summary, nil
+}
+
 func (uc *PullRequestUseCase) GetPRsForReview(ctx context.Context, userID string) ([]entities.PullRequestShort, error) {
 	return uc.prRepo.GetByReviewerID(ctx, userID)
 }
@@ -205,23 +673,13 @@ func (uc *PullRequestUseCase) GetUserPRStats(ctx context.Context, userID string)
 	// Считаем статистику по статусам для authored PRs
 	var authoredStats entities.PRStatusStats
 	for _, pr := range authoredPRs {
-		switch pr.Status {
-		case entities.StatusOpen:
-			authoredStats.Open++
-		case entities.StatusMerged:
-			authoredStats.Merged++
-		}
+		authoredStats.Add(pr.Status)
 	}
 
 	// Считаем статистику по статусам для reviewer PRs
 	var reviewerStats entities.PRStatusStats
 	for _, pr := range reviewerPRs {
-		switch pr.Status {
-		case entities.StatusOpen:
-			reviewerStats.Open++
-		case entities.StatusMerged:
-			reviewerStats.Merged++
-		}
+		reviewerStats.Add(pr.Status)
 	}
 
 	stats := &entities.UserPRStats{
@@ -236,8 +694,259 @@ func (uc *PullRequestUseCase) GetUserPRStats(ctx context.Context, userID string)
 	return stats, nil
 }
 
-func nowPtr() *time.Time {
-	t := time.Now()
+// Журнал решений о назначении ревьюверов PR в порядке их принятия
+func (uc *PullRequestUseCase) GetAssignmentLog(ctx context.Context, prID string) ([]*entities.AssignmentDecision, error) {
+	pr, err := uc.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, err.Error())
+	}
+
+	if pr == nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, "resource not found")
+	}
+
+	return uc.prRepo.GetAssignmentDecisions(ctx, prID)
+}
+
+// Приоритет стратегий: запрос, затем политика команды, затем развертывание
+func (uc *PullRequestUseCase) resolveStrategy(strategy entities.ReviewerStrategy, policy *entities.TeamPolicy) (entities.ReviewerStrategy, error) {
+	if strategy == "" {
+		strategy = policy.Strategy
+	}
+	if strategy == "" {
+		strategy = uc.defaultStrategy
+	}
+	if !strategy.IsValid() {
+		return "", errors.NewDomainError(errors.ErrInvalidRequest, "unknown reviewer strategy: "+string(strategy))
+	}
+	return strategy, nil
+}
+
+// Причина, по которой пользователя нельзя назначить ("" - можно)
+func (sel *selection) exclusionReason(user *entities.User, exclude []string) entities.ExclusionReason {
+	switch {
+	case user.UserID == sel.authorID:
+		return entities.ExcludedAuthor
+	case contains(exclude, user.UserID):
+		return entities.ExcludedAlreadyAssigned
+	case !user.IsActive:
+		return entities.ExcludedInactive
+	case contains(sel.outOfOffice, user.UserID):
+		return entities.ExcludedOutOfOffice
+	case !user.HasCapacity():
+		return entities.ExcludedAtCapacity
+	default:
+		return ""
+	}
+}
+
+// Правило, по которому выбран кандидат: стратегия и сработавшие предпочтения
+func (sel *selection) rule(user *entities.User) string {
+	rule := string(sel.strategy)
+	if len(sel.labels) > 0 && user.MatchesAnySkill(sel.labels) {
+		rule += "+skill_match"
+	}
+	if sel.preferWorkingHours && user.InWorkingHours(sel.now) {
+		rule += "+working_hours"
+	}
+	return rule
+}
+
+// Выбирает до count ревьюверов среди участников команды согласно стратегии
+// и записывает решение по каждому участнику. Непустая role оставляет только участников с ролью не младше нее.
+// Если команда предпочитает рабочие часы, первыми идут кандидаты, у которых сейчас рабочее время,
+// затем кандидаты с навыками по меткам PR; внутри групп порядок стратегии сохраняется.
+// Для round-robin запоминает курсор последнего выбранного, курсоры сохраняются после назначения
+func (uc *PullRequestUseCase) selectFromTeam(
+	ctx context.Context,
+	sel *selection,
+	team *entities.Team,
+	source entities.CandidateSource,
+	exclude []string,
+	count int,
+	role entities.TeamRole,
+) ([]string, error) {
+	selector, err := NewReviewerSelector(sel.strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	if count <= 0 {
+		return nil, nil
+	}
+
+	var candidates []*entities.User
+	for _, member := range team.Members {
+		reason := sel.exclusionReason(member, exclude)
+		if reason == "" && role != "" && !member.Role.AtLeast(role) {
+			reason = entities.ExcludedRole
+		}
+		if reason == "" && sel.skillOnly && !member.MatchesAnySkill(sel.labels) {
+			reason = entities.ExcludedNoSkill
+		}
+		if reason != "" {
+			sel.recordExcluded(member.UserID, team.Name, source, 0, reason)
+			continue
+		}
+		candidates = append(candidates, member)
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	// Курсор нужен только round-robin, загрузка кандидатов уже посчитана в репозитории
+	var state SelectionState
+	if sel.strategy == entities.StrategyRoundRobin {
+		state.LastAssigned, err = uc.teamRepo.GetReviewerCursor(ctx, team.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ranked := selector.Rank(candidates, state)
+	if len(sel.labels) > 0 {
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].MatchesAnySkill(sel.labels) && !ranked[j].MatchesAnySkill(sel.labels)
+		})
+	}
+	if sel.preferWorkingHours {
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].InWorkingHours(sel.now) && !ranked[j].InWorkingHours(sel.now)
+		})
+	}
+
+	var reviewers []string
+	for i, candidate := range ranked {
+		if len(reviewers) >= count {
+			sel.recordExcluded(candidate.UserID, team.Name, source, i+1, entities.ExcludedOutranked)
+			continue
+		}
+		reviewers = append(reviewers, candidate.UserID)
+		sel.recordSelected(candidate.UserID, team.Name, source, i+1, sel.rule(candidate))
+		sel.skillMatched = sel.skillMatched || candidate.MatchesAnySkill(sel.labels)
+	}
+
+	if sel.strategy == entities.StrategyRoundRobin {
+		sel.cursors[team.Name] = reviewers[len(reviewers)-1]
+	}
+
+	return reviewers, nil
+}
+
+// Выбирает до count владельцев измененных путей: сначала указанных пользователей,
+// затем по одному ревьюверу из каждой команды-владельца
+func (uc *PullRequestUseCase) selectOwners(
+	ctx context.Context,
+	sel *selection,
+	paths []string,
+	exclude []string,
+	count int,
+) ([]string, error) {
+	if len(paths) == 0 || count <= 0 {
+		return nil, nil
+	}
+
+	rules, err := uc.ruleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ownerTeams, ownerUsers := matchOwners(rules, paths)
+
+	var reviewers []string
+	for _, userID := range ownerUsers {
+		if len(reviewers) >= count {
+			return reviewers, nil
+		}
+
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err == repositories.ErrUserNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		reason := sel.exclusionReason(user, append(append([]string{}, exclude...), reviewers...))
+		if reason != "" {
+			sel.recordExcluded(userID, "", entities.SourceOwnerUser, 0, reason)
+			continue
+		}
+		reviewers = append(reviewers, userID)
+		sel.recordSelected(userID, "", entities.SourceOwnerUser, 0, "code_owner")
+		sel.skillMatched = sel.skillMatched || user.MatchesAnySkill(sel.labels)
+	}
+
+	for _, teamName := range ownerTeams {
+		if len(reviewers) >= count {
+			break
+		}
+
+		ownerTeam, err := sel.teams.GetByName(ctx, teamName)
+		if err == repositories.ErrTeamNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		excluded := append(append([]string{}, exclude...), reviewers...)
+		selected, err := uc.selectFromTeam(ctx, sel, ownerTeam, entities.SourceOwnerTeam, excluded, 1, "")
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, selected...)
+	}
+
+	return reviewers, nil
+}
+
+// Добирает до count ревьюверов из резервных команд в порядке их приоритета
+func (uc *PullRequestUseCase) selectFromFallbackTeams(
+	ctx context.Context,
+	sel *selection,
+	policy *entities.TeamPolicy,
+	exclude []string,
+	count int,
+) ([]string, error) {
+	var reviewers []string
+	for _, fallbackName := range policy.FallbackTeams {
+		if len(reviewers) >= count {
+			break
+		}
+
+		fallbackTeam, err := sel.teams.GetByName(ctx, fallbackName)
+		if err == repositories.ErrTeamNotFound {
+			continue // резервную команду могли удалить
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		excluded := append(append([]string{}, exclude...), reviewers...)
+		selected, err := uc.selectFromTeam(ctx, sel, fallbackTeam, entities.SourceFallbackTeam, excluded,
+			count-len(reviewers), "")
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, selected...)
+	}
+
+	return reviewers, nil
+}
+
+// Сохраняет курсоры round-robin после успешного назначения
+func (uc *PullRequestUseCase) saveCursors(ctx context.Context, sel *selection) error {
+	for teamName, userID := range sel.cursors {
+		if err := uc.teamRepo.SaveReviewerCursor(ctx, teamName, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *PullRequestUseCase) nowPtr() *time.Time {
+	t := uc.clock.Now()
 	return &t
 }
 
@@ -249,3 +958,20 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
+
+func remove(slice []string, item string) []string {
+	var result []string
+	for _, s := range slice {
+		if s != item {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Записывает value в target, если значение передано
+func setIfPresent[T any](target *T, value *T) {
+	if value != nil {
+		*target = *value
+	}
+}
@@ -0,0 +1,191 @@
+package usecases
+
+import (
+	"context"
+	"log"
+
+	"go-project/internal/domain/entities"
+	"go-project/internal/domain/errors"
+	"go-project/internal/domain/repositories"
+)
+
+// ReviewSLAUseCase обрабатывает ревью, просроченные по SLA команды PR:
+// ревьювер заменяется или в ревьюверы добавляется тимлид, в зависимости от политики команды
+type ReviewSLAUseCase struct {
+	prRepo    repositories.PullRequestRepository
+	teamRepo  repositories.TeamRepository
+	prUseCase *PullRequestUseCase
+	txManager repositories.TxManager
+}
+
+func NewReviewSLAUseCase(
+	prRepo repositories.PullRequestRepository,
+	teamRepo repositories.TeamRepository,
+	prUseCase *PullRequestUseCase,
+	txManager repositories.TxManager,
+) *ReviewSLAUseCase {
+	return &ReviewSLAUseCase{
+		prRepo:    prRepo,
+		teamRepo:  teamRepo,
+		prUseCase: prUseCase,
+		txManager: txManager,
+	}
+}
+
+// Обрабатывает все просроченные на текущий момент ревью, каждое в своей транзакции.
+// Ошибка по одному ревью пишется в лог и не мешает обработке остальных.
+// Возвращает записи истории, созданные за этот запуск
+func (uc *ReviewSLAUseCase) ProcessOverdueReviews(ctx context.Context) ([]*entities.ReviewEscalation, error) {
+	overdue, err := uc.prRepo.GetOverdueReviews(ctx, uc.prUseCase.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	escalations := []*entities.ReviewEscalation{}
+	for _, review := range overdue {
+		var escalation *entities.ReviewEscalation
+		err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			// Кэш команд и счетчики нагрузки свои у каждой транзакции:
+			// изменения откаченной транзакции не должны влиять на следующие ревью
+			env, err := uc.prUseCase.newAssignmentEnv(ctx)
+			if err != nil {
+				return err
+			}
+			escalation, err = uc.processOverdueReview(ctx, env, review)
+			return err
+		})
+		if err != nil {
+			log.Printf("Review SLA: failed to process review of %s on %s: %v",
+				review.ReviewerID, review.PullRequestID, err)
+			continue
+		}
+		escalations = append(escalations, escalation)
+	}
+
+	return escalations, nil
+}
+
+// История обработки просроченных ревью команды
+func (uc *ReviewSLAUseCase) GetEscalations(ctx context.Context, teamName string) ([]*entities.ReviewEscalation, error) {
+	if _, err := uc.teamRepo.GetByName(ctx, teamName); err != nil {
+		return nil, errors.NewDomainError(errors.ErrNotFound, "team not found")
+	}
+
+	return uc.prRepo.GetEscalations(ctx, teamName)
+}
+
+func (uc *ReviewSLAUseCase) processOverdueReview(
+	ctx context.Context,
+	env *assignmentEnv,
+	review *entities.OverdueReview,
+) (*entities.ReviewEscalation, error) {
+	pr, err := uc.prRepo.GetByID(ctx, review.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := env.teams.GetPolicy(ctx, review.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	escalation := &entities.ReviewEscalation{
+		PullRequestID: review.PullRequestID,
+		ReviewerID:    review.ReviewerID,
+		TeamName:      review.TeamName,
+		Action:        policy.SLAAction,
+		AssignedAt:    review.AssignedAt,
+		CreatedAt:     env.now,
+	}
+
+	// Закрепленного ревьювера не заменяем: для него действуем так же, как когда заменить некем
+	if policy.SLAAction == entities.SLAActionReassign && !contains(pr.PinnedReviewers, review.ReviewerID) {
+		newReviewer, sel, err := uc.prUseCase.replaceReviewer(ctx, env, pr, review.ReviewerID, "")
+		if err != nil {
+			return nil, err
+		}
+
+		if newReviewer != "" {
+			if err := uc.prRepo.Update(ctx, pr); err != nil {
+				return nil, err
+			}
+
+			decision := sel.decision(pr.ID, entities.ActionReassign)
+			decision.ReplacedUserID = review.ReviewerID
+			if err := uc.prRepo.SaveAssignmentDecision(ctx, decision); err != nil {
+				return nil, err
+			}
+			if err := uc.prUseCase.saveCursors(ctx, sel); err != nil {
+				return nil, err
+			}
+
+			escalation.NewReviewerID = newReviewer
+			return escalation, uc.prRepo.SaveEscalation(ctx, escalation)
+		}
+	}
+
+	// Заменить некем - эскалируем тимлиду, если он задан
+	if policy.SLAAction == entities.SLAActionReassign && policy.TeamLeadID != "" {
+		escalation.Action = entities.SLAActionEscalate
+	}
+
+	if escalation.Action == entities.SLAActionEscalate {
+		escalation.NewReviewerID, err = uc.escalateToLead(ctx, env, pr, review, policy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return escalation, uc.prRepo.SaveEscalation(ctx, escalation)
+}
+
+// Добавляет тимлида в ревьюверы PR сверх лимита ревью.
+// Возвращает тимлида или пустую строку, если эскалировать некому (в том числе если просрочил сам тимлид
+// или после изменения политики он лишился роли lead)
+func (uc *ReviewSLAUseCase) escalateToLead(
+	ctx context.Context,
+	env *assignmentEnv,
+	pr *entities.PullRequest,
+	review *entities.OverdueReview,
+	policy *entities.TeamPolicy,
+) (string, error) {
+	team, err := env.teams.GetByName(ctx, review.TeamName)
+	if err != nil {
+		return "", err
+	}
+
+	var lead *entities.User
+	for _, member := range team.Members {
+		if member.UserID == policy.TeamLeadID && member.Role == entities.RoleLead {
+			lead = member
+		}
+	}
+	if lead == nil || !lead.IsActive || lead.UserID == pr.AuthorID || lead.UserID == review.ReviewerID {
+		return "", nil
+	}
+
+	// Тимлид уже среди ревьюверов - повторно не назначаем
+	if contains(pr.AssignedReviewers, lead.UserID) {
+		return lead.UserID, nil
+	}
+
+	pr.AssignedReviewers = append(pr.AssignedReviewers, lead.UserID)
+	pr.UpdatedAt = env.nowPtr()
+	if err := uc.prRepo.Update(ctx, pr); err != nil {
+		return "", err
+	}
+	env.teams.addOpenReview(lead.UserID)
+
+	strategy, err := uc.prUseCase.resolveStrategy("", policy)
+	if err != nil {
+		return "", err
+	}
+
+	sel := env.newSelection(strategy, policy, pr.AuthorID, pr.Labels)
+	sel.recordSelected(lead.UserID, review.TeamName, entities.SourceTeamLead, 0, "team_lead")
+	if err := uc.prRepo.SaveAssignmentDecision(ctx, sel.decision(pr.ID, entities.ActionEscalate)); err != nil {
+		return "", err
+	}
+
+	return lead.UserID, nil
+}
@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"math/rand/v2"
+	"sort"
+
+	"go-project/internal/domain/entities"
+	"go-project/internal/domain/errors"
+)
+
+// SelectionState - данные, которые нужны стратегиям помимо самих кандидатов
+type SelectionState struct {
+	// LastAssigned - последний ревьювер, назначенный в команде (курсор round-robin)
+	LastAssigned string
+}
+
+// ReviewerSelector - стратегия выбора ревьюверов.
+// Rank возвращает кандидатов в порядке приоритета назначения и не меняет входной срез.
+type ReviewerSelector interface {
+	Rank(candidates []*entities.User, state SelectionState) []*entities.User
+}
+
+func NewReviewerSelector(strategy entities.ReviewerStrategy) (ReviewerSelector, error) {
+	switch strategy {
+	case entities.StrategyRandom:
+		return randomSelector{}, nil
+	case entities.StrategyRoundRobin:
+		return roundRobinSelector{}, nil
+	case entities.StrategyLeastOpenReviews:
+		return leastOpenReviewsSelector{}, nil
+	default:
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "unknown reviewer strategy: "+string(strategy))
+	}
+}
+
+// Случайный порядок
+type randomSelector struct{}
+
+func (randomSelector) Rank(candidates []*entities.User, _ SelectionState) []*entities.User {
+	ranked := sortedByID(candidates)
+	rand.Shuffle(len(ranked), func(i, j int) {
+		ranked[i], ranked[j] = ranked[j], ranked[i]
+	})
+	return ranked
+}
+
+// По кругу: начинаем с кандидата, следующего за последним назначенным
+type roundRobinSelector struct{}
+
+func (roundRobinSelector) Rank(candidates []*entities.User, state SelectionState) []*entities.User {
+	ranked := sortedByID(candidates)
+	if state.LastAssigned == "" {
+		return ranked
+	}
+
+	// Курсор может указывать на пользователя, которого уже нет среди кандидатов,
+	// поэтому ищем первого с ID больше курсора, а не сам курсор
+	start := sort.Search(len(ranked), func(i int) bool {
+		return ranked[i].UserID > state.LastAssigned
+	})
+	return append(ranked[start:], ranked[:start]...)
+}
+
+// Сначала кандидаты с наименьшим числом открытых ревью
+type leastOpenReviewsSelector struct{}
+
+func (leastOpenReviewsSelector) Rank(candidates []*entities.User, _ SelectionState) []*entities.User {
+	ranked := sortedByID(candidates)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].OpenReviews < ranked[j].OpenReviews
+	})
+	return ranked
+}
+
+// Копия кандидатов, упорядоченная по ID, чтобы результат не зависел от порядка строк из БД
+func sortedByID(candidates []*entities.User) []*entities.User {
+	sorted := make([]*entities.User, len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UserID < sorted[j].UserID
+	})
+	return sorted
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+
+	"go-project/internal/domain/entities"
+	"go-project/internal/domain/repositories"
+)
+
+// teamCache - команды и политики, прочитанные за время одной операции назначения.
+// Массовая замена ревьюверов переиспользует кэш между PR и учитывает новые назначения
+// в памяти, вместо того чтобы перечитывать команды после каждой замены
+type teamCache struct {
+	teamRepo repositories.TeamRepository
+	teams    map[string]*entities.Team       // по имени команды
+	byUser   map[string]string               // основная команда пользователя
+	policies map[string]*entities.TeamPolicy // по имени команды
+}
+
+func newTeamCache(teamRepo repositories.TeamRepository) *teamCache {
+	return &teamCache{
+		teamRepo: teamRepo,
+		teams:    make(map[string]*entities.Team),
+		byUser:   make(map[string]string),
+		policies: make(map[string]*entities.TeamPolicy),
+	}
+}
+
+func (c *teamCache) GetByName(ctx context.Context, name string) (*entities.Team, error) {
+	if team, ok := c.teams[name]; ok {
+		return team, nil
+	}
+
+	team, err := c.teamRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	c.teams[name] = team
+	return team, nil
+}
+
+func (c *teamCache) GetByUserID(ctx context.Context, userID string) (*entities.Team, error) {
+	if name, ok := c.byUser[userID]; ok {
+		return c.GetByName(ctx, name)
+	}
+
+	team, err := c.teamRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if cached, ok := c.teams[team.Name]; ok {
+		team = cached
+	} else {
+		c.teams[team.Name] = team
+	}
+	c.byUser[userID] = team.Name
+	return team, nil
+}
+
+// Команда, сохраненная в PR. У PR без команды (его команду удалили, а другой у автора не было,
+// или автор ни в одной команде не состоял) это текущая основная команда автора, а если ее нет -
+// команда без имени и участников, для которой действует политика по умолчанию
+func (c *teamCache) GetByPR(ctx context.Context, pr *entities.PullRequest) (*entities.Team, error) {
+	if pr.TeamName != "" {
+		return c.GetByName(ctx, pr.TeamName)
+	}
+
+	team, err := c.GetByUserID(ctx, pr.AuthorID)
+	if err == repositories.ErrTeamNotFound {
+		return &entities.Team{Members: []*entities.User{}}, nil
+	}
+	return team, err
+}
+
+// Политика команды; для команды без имени (PR без команды) - политика по умолчанию
+func (c *teamCache) GetPolicy(ctx context.Context, teamName string) (*entities.TeamPolicy, error) {
+	if teamName == "" {
+		return entities.DefaultTeamPolicy(""), nil
+	}
+	if policy, ok := c.policies[teamName]; ok {
+		return policy, nil
+	}
+
+	policy, err := c.teamRepo.GetPolicy(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	c.policies[teamName] = policy
+	return policy, nil
+}
+
+// Учитывает новое открытое ревью пользователя во всех загруженных командах
+func (c *teamCache) addOpenReview(userID string) {
+	for _, team := range c.teams {
+		for _, member := range team.Members {
+			if member.UserID == userID {
+				member.OpenReviews++
+			}
+		}
+	}
+}
+
+// Учитывает снятое открытое ревью пользователя во всех загруженных командах
+func (c *teamCache) removeOpenReview(userID string) {
+	for _, team := range c.teams {
+		for _, member := range team.Members {
+			if member.UserID == userID {
+				member.OpenReviews--
+			}
+		}
+	}
+}
@@ -6,78 +6,256 @@ import (
 	"go-project/internal/domain/entities"
 	"go-project/internal/domain/errors"
 	"go-project/internal/domain/repositories"
+	"log"
+	"slices"
+	"strings"
 )
 
 type TeamUseCase struct {
-	teamRepo repositories.TeamRepository
-	userRepo repositories.UserRepository
+	teamRepo  repositories.TeamRepository
+	userRepo  repositories.UserRepository
+	prUseCase *PullRequestUseCase
+	txManager repositories.TxManager
 }
 
-func NewTeamUseCase(teamRepo repositories.TeamRepository, userRepo repositories.UserRepository) *TeamUseCase {
+func NewTeamUseCase(
+	teamRepo repositories.TeamRepository,
+	userRepo repositories.UserRepository,
+	prUseCase *PullRequestUseCase,
+	txManager repositories.TxManager,
+) *TeamUseCase {
 	return &TeamUseCase{
-		teamRepo: teamRepo,
-		userRepo: userRepo,
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+		prUseCase: prUseCase,
+		txManager: txManager,
 	}
 }
 
 func (uc *TeamUseCase) CreateTeam(ctx context.Context, team *entities.Team) error {
+	if team.DefaultMaxOpenReviews == 0 {
+		team.DefaultMaxOpenReviews = entities.DefaultMaxOpenReviews
+	}
+	if team.DefaultMaxOpenReviews < 0 {
+		return errors.NewDomainError(errors.ErrInvalidRequest, "default_max_open_reviews must be positive")
+	}
+	if err := validateMembers(team.Members); err != nil {
+		return err
+	}
+
 	// Проверяем существование команды
 	existing, _ := uc.teamRepo.GetByName(ctx, team.Name)
 	if existing != nil {
 		return errors.NewDomainError(errors.ErrTeamExists, "team_name already exists")
 	}
 
-	// Проверяем что пользователи не состоят в других командах
-	for _, member := range team.Members {
-		existingTeam, _ := uc.teamRepo.GetByUserID(ctx, member.UserID)
-		if existingTeam != nil {
-			return errors.NewDomainError(errors.ErrUserInAnotherTeam,
-				fmt.Sprintf("user %s already in team %s", member.UserID, existingTeam.Name))
+	// Пользователей и команду создаем в одной транзакции, чтобы при ошибке не оставить лишних пользователей
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		deactivated, err := uc.saveUsers(ctx, team.Members)
+		if err != nil {
+			return err
 		}
+		if err := uc.teamRepo.Create(ctx, team); err != nil {
+			return err
+		}
+		_, err = uc.prUseCase.reassignOpenReviews(ctx, deactivated, "")
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
-	// 1. Сначала создаем/обновляем всех пользователей
-	var createdUsers []string // Запоминаем созданных пользователей для отката
+	// Заполняем вычисляемые поля для ответа
 	for _, member := range team.Members {
-		existingUser, _ := uc.userRepo.GetByID(ctx, member.UserID)
-		if existingUser == nil {
-			user := &entities.User{
-				UserID:   member.UserID,
-				Username: member.Username,
-				IsActive: member.IsActive,
-			}
-			if err := uc.userRepo.Create(ctx, user); err != nil {
-				// Откатываем созданных пользователей
-				for _, userID := range createdUsers {
-					_ = uc.userRepo.Delete(ctx, userID)
-				}
-				return err
-			}
-			createdUsers = append(createdUsers, member.UserID)
-		} else {
-			// Обновляем пользователя если нужно
-			existingUser.Username = member.Username
-			existingUser.IsActive = member.IsActive
-			if err := uc.userRepo.Update(ctx, existingUser); err != nil {
-				// Откатываем созданных пользователей
-				for _, userID := range createdUsers {
-					_ = uc.userRepo.Delete(ctx, userID)
-				}
-				return err
-			}
+		member.ReviewCapacity = team.DefaultMaxOpenReviews
+		if member.MaxOpenReviews != nil {
+			member.ReviewCapacity = *member.MaxOpenReviews
 		}
 	}
 
-	// 2. Теперь создаем команду и добавляем участников
-	if err := uc.teamRepo.Create(ctx, team); err != nil {
-		// Откатываем созданных пользователей
-		for _, userID := range createdUsers {
-			_ = uc.userRepo.Delete(ctx, userID)
+	return nil
+}
+
+// Заменяет состав команды и лимит по умолчанию (0 - оставить прежний). Пользователи создаются
+// или обновляются, как в CreateTeam, их участие в других командах сохраняется.
+// Открытые ревью выбывших участников в PR команды и деактивированных запросом пользователей
+// во всех PR переназначаются в той же транзакции
+func (uc *TeamUseCase) UpdateTeam(ctx context.Context, team *entities.Team) (*entities.Team, *ReassignmentSummary, error) {
+	if team.DefaultMaxOpenReviews < 0 {
+		return nil, nil, errors.NewDomainError(errors.ErrInvalidRequest, "default_max_open_reviews must be positive")
+	}
+	if err := validateMembers(team.Members); err != nil {
+		return nil, nil, err
+	}
+
+	existing, err := uc.GetTeam(ctx, team.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if team.DefaultMaxOpenReviews == 0 {
+		team.DefaultMaxOpenReviews = existing.DefaultMaxOpenReviews
+	}
+
+	var removed []string
+	for _, member := range existing.Members {
+		if !slices.ContainsFunc(team.Members, func(user *entities.User) bool {
+			return user.UserID == member.UserID
+		}) {
+			removed = append(removed, member.UserID)
 		}
+	}
+
+	var summary *ReassignmentSummary
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		deactivated, err := uc.saveUsers(ctx, team.Members)
+		if err != nil {
+			return err
+		}
+		if err := uc.teamRepo.Update(ctx, team); err != nil {
+			return err
+		}
+
+		// Ревью деактивированных заменяются во всех PR, как в SetUserActive, ревью выбывших - в PR этой команды
+		if summary, err = uc.prUseCase.reassignOpenReviews(ctx, deactivated, ""); err != nil {
+			return err
+		}
+		removedSummary, err := uc.prUseCase.reassignOpenReviews(ctx, removed, team.Name)
+		if err != nil {
+			return err
+		}
+		summary.merge(removedSummary)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated, err := uc.teamRepo.GetByName(ctx, team.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, summary, nil
+}
+
+// Добавляет существующего пользователя в команду (пустая роль - member)
+func (uc *TeamUseCase) AddMember(ctx context.Context, teamName, userID string, role entities.TeamRole) (*entities.Team, error) {
+	if role == "" {
+		role = entities.RoleMember
+	}
+	if !role.IsValid() {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "unknown role: "+string(role))
+	}
+
+	team, err := uc.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if slices.ContainsFunc(team.Members, func(member *entities.User) bool {
+		return member.UserID == userID
+	}) {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest,
+			fmt.Sprintf("user %s is already a member of team %s", userID, teamName))
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, errors.NewDomainError(errors.ErrNotFound, "user not found")
+		}
+		return nil, err
+	}
+
+	if err := uc.teamRepo.AddMember(ctx, teamName, userID, role); err != nil {
+		return nil, err
+	}
+
+	return uc.teamRepo.GetByName(ctx, teamName)
+}
+
+// Убирает пользователя из команды и одной транзакцией переназначает его открытые ревью в PR команды.
+// В PR других команд пользователь остается ревьювером
+func (uc *TeamUseCase) RemoveMember(ctx context.Context, teamName, userID string) (*entities.Team, *ReassignmentSummary, error) {
+	if _, err := uc.GetTeam(ctx, teamName); err != nil {
+		return nil, nil, err
+	}
+
+	var summary *ReassignmentSummary
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.teamRepo.RemoveMember(ctx, teamName, userID); err != nil {
+			if err == repositories.ErrTeamMemberNotFound {
+				return errors.NewDomainError(errors.ErrNotFound,
+					fmt.Sprintf("user %s is not a member of team %s", userID, teamName))
+			}
+			return err
+		}
+
+		var err error
+		summary, err = uc.prUseCase.reassignOpenReviews(ctx, []string{userID}, teamName)
 		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return nil
+	team, err := uc.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return team, summary, nil
+}
+
+// Переименовывает команду. Участники, политика, история эскалаций и правила владения переходят к новому имени
+func (uc *TeamUseCase) RenameTeam(ctx context.Context, teamName, newName string) (*entities.Team, error) {
+	if newName == "" {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "new_team_name is required")
+	}
+
+	if _, err := uc.GetTeam(ctx, teamName); err != nil {
+		return nil, err
+	}
+	if existing, _ := uc.teamRepo.GetByName(ctx, newName); existing != nil {
+		return nil, errors.NewDomainError(errors.ErrTeamExists, "team_name already exists")
+	}
+
+	if err := uc.teamRepo.Rename(ctx, teamName, newName); err != nil {
+		return nil, err
+	}
+
+	return uc.teamRepo.GetByName(ctx, newName)
+}
+
+// Удаляет команду и возвращает открытые PR и черновики ее авторов. Если они есть, удаление
+// без force отклоняется. С force PR остаются с прежними ревьюверами и переходят в следующую
+// команду автора; если других команд нет, PR остается без команды (см. teamCache.GetByPR).
+// Вместе с командой удаляются ее политика и история обработки просроченных ревью
+func (uc *TeamUseCase) DeleteTeam(ctx context.Context, teamName string, force bool) ([]string, error) {
+	var openPRs []string
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Проверка открытых PR и удаление под одной блокировкой команды: PR, созданный между ними,
+		// не перейдет в другую команду без force
+		err := uc.teamRepo.Lock(ctx, teamName)
+		if err == repositories.ErrTeamNotFound {
+			return errors.NewDomainError(errors.ErrNotFound, "resource not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		openPRs, err = uc.prUseCase.prRepo.GetOpenIDsByTeam(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		if len(openPRs) > 0 && !force {
+			return errors.NewDomainError(errors.ErrTeamHasOpenPRs,
+				fmt.Sprintf("team has %d open pull requests: %s", len(openPRs), strings.Join(openPRs, ", ")))
+		}
+
+		return uc.teamRepo.Delete(ctx, teamName)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return openPRs, nil
 }
 
 func (uc *TeamUseCase) GetTeam(ctx context.Context, teamName string) (*entities.Team, error) {
@@ -91,3 +269,247 @@ func (uc *TeamUseCase) GetTeam(ctx context.Context, teamName string) (*entities.
 
 	return team, nil
 }
+
+// Страница списка команд с числом участников, активных и занятых ревью.
+// Возвращает курсор следующей страницы ("" - страница последняя)
+func (uc *TeamUseCase) ListTeams(ctx context.Context, prefix, cursor string, limit int) ([]*entities.TeamSummary, string, error) {
+	limit, err := pageSize(limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filter := entities.TeamListFilter{Prefix: prefix, Limit: limit + 1}
+	if err := decodeCursor(cursor, &filter.After); err != nil {
+		return nil, "", err
+	}
+
+	teams, err := uc.teamRepo.List(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Лишняя запись означает, что есть следующая страница
+	if len(teams) <= limit {
+		return teams, "", nil
+	}
+	teams = teams[:limit]
+	return teams, encodeCursor(teams[limit-1].TeamName), nil
+}
+
+func (uc *TeamUseCase) GetPolicy(ctx context.Context, teamName string) (*entities.TeamPolicy, error) {
+	if _, err := uc.GetTeam(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	return uc.teamRepo.GetPolicy(ctx, teamName)
+}
+
+func (uc *TeamUseCase) UpdatePolicy(ctx context.Context, policy *entities.TeamPolicy) (*entities.TeamPolicy, error) {
+	if policy.RequiredReviewers <= 0 {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "required_reviewers must be positive")
+	}
+	if policy.MinApprovals < 0 || policy.MinApprovals > policy.RequiredReviewers {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "min_approvals must be between 0 and required_reviewers")
+	}
+	if policy.Strategy != "" && !policy.Strategy.IsValid() {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "unknown reviewer strategy: "+string(policy.Strategy))
+	}
+	if policy.ReviewSLAHours < 0 {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "review_sla_hours must not be negative")
+	}
+	if policy.SLAAction == "" {
+		policy.SLAAction = entities.SLAActionReassign
+	}
+	if !policy.SLAAction.IsValid() {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "unknown sla_action: "+string(policy.SLAAction))
+	}
+	if policy.SLAAction == entities.SLAActionEscalate && policy.TeamLeadID == "" {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "team_lead_id is required for sla_action escalate")
+	}
+	if policy.MinRoleReviewers < 0 || policy.MinRoleReviewers > policy.RequiredReviewers {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "min_role_reviewers must be between 0 and required_reviewers")
+	}
+	if policy.RequiredRole != "" && !policy.RequiredRole.IsValid() {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "unknown required_role: "+string(policy.RequiredRole))
+	}
+	if policy.MinRoleReviewers > 0 && policy.RequiredRole == "" {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "required_role is required for min_role_reviewers")
+	}
+	if policy.LargePRLines < 0 {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "large_pr_lines must not be negative")
+	}
+	if policy.LargePRLines > 0 && policy.LargePRReviewers < policy.RequiredReviewers {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "large_pr_reviewers must not be less than required_reviewers")
+	}
+
+	team, err := uc.GetTeam(ctx, policy.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Тимлид должен состоять в команде с ролью lead
+	if policy.TeamLeadID != "" && !slices.ContainsFunc(team.Members, func(member *entities.User) bool {
+		return member.UserID == policy.TeamLeadID && member.Role == entities.RoleLead
+	}) {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest,
+			fmt.Sprintf("team lead %s is not a member of team %s with role lead", policy.TeamLeadID, policy.TeamName))
+	}
+
+	// Резервные команды должны существовать и не повторяться
+	if policy.FallbackTeams == nil {
+		policy.FallbackTeams = []string{}
+	}
+	seen := make(map[string]bool)
+	for _, fallbackName := range policy.FallbackTeams {
+		if fallbackName == policy.TeamName || seen[fallbackName] {
+			return nil, errors.NewDomainError(errors.ErrInvalidRequest,
+				fmt.Sprintf("invalid fallback team %s", fallbackName))
+		}
+		if _, err := uc.teamRepo.GetByName(ctx, fallbackName); err != nil {
+			return nil, errors.NewDomainError(errors.ErrNotFound,
+				fmt.Sprintf("fallback team %s not found", fallbackName))
+		}
+		seen[fallbackName] = true
+	}
+
+	if err := uc.teamRepo.SavePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// Деактивирует участников команды и заменяет их во всех OPEN PR одной транзакцией.
+// Если для PR замены не нашлось, ревьювер остается назначенным, а PR попадает в unassignable
+func (uc *TeamUseCase) DeactivateMembers(ctx context.Context, teamName string, userIDs []string) (*ReassignmentSummary, error) {
+	if len(userIDs) == 0 {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "user_ids must not be empty")
+	}
+
+	team, err := uc.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]string, 0, len(team.Members))
+	for _, member := range team.Members {
+		members = append(members, member.UserID)
+	}
+	for _, userID := range userIDs {
+		if !contains(members, userID) {
+			return nil, errors.NewDomainError(errors.ErrNotFound,
+				fmt.Sprintf("user %s is not a member of team %s", userID, teamName))
+		}
+	}
+
+	var summary *ReassignmentSummary
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.SetActiveMany(ctx, userIDs, false); err != nil {
+			return err
+		}
+
+		summary, err = uc.prUseCase.reassignOpenReviews(ctx, userIDs, "")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// Перераспределяет неначатые ревью между участниками команды одной транзакцией и возвращает все переносы
+func (uc *TeamUseCase) RebalanceTeam(ctx context.Context, teamName string) ([]*entities.ReviewMove, error) {
+	if _, err := uc.GetTeam(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	var moves []*entities.ReviewMove
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		moves, err = uc.prUseCase.rebalanceTeam(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return moves, nil
+}
+
+// Перераспределяет нагрузку во всех командах с auto_rebalance, каждую в своей транзакции.
+// Ошибка по одной команде пишется в лог и не мешает перераспределению в остальных
+func (uc *TeamUseCase) RebalanceAutoTeams(ctx context.Context) ([]*entities.ReviewMove, error) {
+	teamNames, err := uc.teamRepo.GetAutoRebalanceTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	moves := []*entities.ReviewMove{}
+	for _, teamName := range teamNames {
+		teamMoves, err := uc.RebalanceTeam(ctx, teamName)
+		if err != nil {
+			log.Printf("Rebalance: failed to rebalance team %s: %v", teamName, err)
+			continue
+		}
+		moves = append(moves, teamMoves...)
+	}
+
+	return moves, nil
+}
+
+// Проверяет лимиты и роли участников, пустую роль заменяет на member
+func validateMembers(members []*entities.User) error {
+	for _, member := range members {
+		if member.MaxOpenReviews != nil && *member.MaxOpenReviews <= 0 {
+			return errors.NewDomainError(errors.ErrInvalidRequest,
+				fmt.Sprintf("max_open_reviews of user %s must be positive", member.UserID))
+		}
+		if member.Role == "" {
+			member.Role = entities.RoleMember
+		}
+		if !member.Role.IsValid() {
+			return errors.NewDomainError(errors.ErrInvalidRequest,
+				fmt.Sprintf("unknown role %s of user %s", member.Role, member.UserID))
+		}
+	}
+	return nil
+}
+
+// Создает отсутствующих пользователей, у существующих обновляет имя, активность и персональный лимит
+// (только если он передан явно). Возвращает существующих пользователей, которых запрос деактивировал:
+// их открытые ревью вызывающий переназначает в той же транзакции, как SetUserActive
+func (uc *TeamUseCase) saveUsers(ctx context.Context, members []*entities.User) ([]string, error) {
+	var deactivated []string
+	for _, member := range members {
+		existing, err := uc.userRepo.GetByID(ctx, member.UserID)
+		if err == repositories.ErrUserNotFound {
+			user := &entities.User{
+				UserID:         member.UserID,
+				Username:       member.Username,
+				IsActive:       member.IsActive,
+				MaxOpenReviews: member.MaxOpenReviews,
+			}
+			if err := uc.userRepo.Create(ctx, user); err != nil {
+				return nil, userError(err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if existing.IsActive && !member.IsActive {
+			deactivated = append(deactivated, member.UserID)
+		}
+		existing.Username = member.Username
+		existing.IsActive = member.IsActive
+		if member.MaxOpenReviews != nil {
+			existing.MaxOpenReviews = member.MaxOpenReviews
+		}
+		if err := uc.userRepo.Update(ctx, existing); err != nil {
+			return nil, userError(err)
+		}
+	}
+	return deactivated, nil
+}
@@ -5,43 +5,273 @@ import (
 	"go-project/internal/domain/entities"
 	"go-project/internal/domain/errors"
 	"go-project/internal/domain/repositories"
+	"slices"
+	"time"
 )
 
 type UserUseCase struct {
-	userRepo repositories.UserRepository
-	teamRepo repositories.TeamRepository
+	userRepo  repositories.UserRepository
+	teamRepo  repositories.TeamRepository
+	prUseCase *PullRequestUseCase
+	txManager repositories.TxManager
 }
 
-func NewUserUseCase(userRepo repositories.UserRepository, teamRepo repositories.TeamRepository) *UserUseCase {
+func NewUserUseCase(
+	userRepo repositories.UserRepository,
+	teamRepo repositories.TeamRepository,
+	prUseCase *PullRequestUseCase,
+	txManager repositories.TxManager,
+) *UserUseCase {
 	return &UserUseCase{
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		userRepo:  userRepo,
+		teamRepo:  teamRepo,
+		prUseCase: prUseCase,
+		txManager: txManager,
 	}
 }
 
-func (uc *UserUseCase) SetUserActive(ctx context.Context, userID string, isActive bool) (*entities.User, string, error) {
-	user, err := uc.userRepo.GetByID(ctx, userID)
+// Создает пользователя вне команды
+func (uc *UserUseCase) CreateUser(ctx context.Context, user *entities.User) (*entities.User, error) {
+	if user.UserID == "" || user.Username == "" {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "user_id and username are required")
+	}
+	if user.MaxOpenReviews != nil && *user.MaxOpenReviews <= 0 {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "max_open_reviews must be positive")
+	}
+
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, userError(err)
+	}
+
+	return uc.userRepo.GetByID(ctx, user.UserID)
+}
+
+// Меняет имя пользователя. Доступность, лимит, навыки и рабочие часы меняются отдельными методами
+func (uc *UserUseCase) UpdateUser(ctx context.Context, userID, username string) (*entities.User, error) {
+	if username == "" {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "username is required")
+	}
+
+	user, err := uc.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Username = username
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, userError(err)
+	}
+
+	return user, nil
+}
+
+// Страница списка пользователей по фильтру. Возвращает курсор следующей страницы ("" - страница последняя)
+func (uc *UserUseCase) ListUsers(ctx context.Context, filter entities.UserListFilter, cursor string) ([]*entities.User, string, error) {
+	limit, err := pageSize(filter.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := decodeCursor(cursor, &filter.After); err != nil {
+		return nil, "", err
+	}
+
+	filter.Limit = limit + 1
+	users, err := uc.userRepo.List(ctx, filter)
 	if err != nil {
 		return nil, "", err
 	}
 
+	// Лишняя запись означает, что есть следующая страница
+	if len(users) <= limit {
+		return users, "", nil
+	}
+	users = users[:limit]
+	return users, encodeCursor(users[limit-1].UserID), nil
+}
+
+// Меняет доступность пользователя. При деактивации его ревью в OPEN PR переназначаются
+// по правилам ReassignReviewer; PR без замены остаются с флагом needs_reviewer.
+// Итог переназначения возвращается только при деактивации
+func (uc *UserUseCase) SetUserActive(ctx context.Context, userID string, isActive bool) (*entities.User, string, *ReassignmentSummary, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
 	if user == nil {
-		return nil, "", errors.NewDomainError(errors.ErrNotFound, "user not found")
+		return nil, "", nil, errors.NewDomainError(errors.ErrNotFound, "user not found")
 	}
 
-	user.IsActive = isActive
+	// Меняем только доступность, загрузка пользователя вычисляется по его открытым ревью
+	var summary *ReassignmentSummary
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.userRepo.SetActive(ctx, userID, isActive); err != nil {
+			return err
+		}
+		if isActive {
+			return nil
+		}
 
-	if err := uc.userRepo.Update(ctx, user); err != nil {
-		return nil, "", err
+		summary, err = uc.prUseCase.reassignOpenReviews(ctx, []string{userID}, "")
+		return err
+	})
+	if err != nil {
+		return nil, "", nil, err
 	}
+	user.IsActive = isActive
 
 	team, err := uc.teamRepo.GetByUserID(ctx, userID)
 	if err != nil {
 		if err == repositories.ErrTeamNotFound {
-			return user, "", nil
+			return user, "", summary, nil
 		}
-		return nil, "", err
+		return nil, "", nil, err
+	}
+
+	return user, team.Name, summary, nil
+}
+
+// Команды пользователя, основная первой
+func (uc *UserUseCase) GetTeams(ctx context.Context, userID string) ([]*entities.TeamMembership, error) {
+	if _, err := uc.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.teamRepo.GetMemberships(ctx, userID)
+}
+
+// Выбирает основную команду пользователя: от ее имени он создает PR, ее политика действует для них.
+// Пользователь должен состоять в команде; "" сбрасывает выбор
+func (uc *UserUseCase) SetPrimaryTeam(ctx context.Context, userID, teamName string) ([]*entities.TeamMembership, error) {
+	memberships, err := uc.GetTeams(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if teamName != "" && !slices.ContainsFunc(memberships, func(membership *entities.TeamMembership) bool {
+		return membership.TeamName == teamName
+	}) {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "user is not a member of team "+teamName)
 	}
 
-	return user, team.Name, nil
+	if err := uc.userRepo.SetPrimaryTeam(ctx, userID, teamName); err != nil {
+		return nil, err
+	}
+
+	return uc.teamRepo.GetMemberships(ctx, userID)
+}
+
+// Устанавливает персональный лимит открытых ревью (nil - использовать лимит команды)
+func (uc *UserUseCase) SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) (*entities.User, error) {
+	if maxOpenReviews != nil && *maxOpenReviews <= 0 {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "max_open_reviews must be positive")
+	}
+
+	if err := uc.userRepo.SetMaxOpenReviews(ctx, userID, maxOpenReviews); err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, errors.NewDomainError(errors.ErrNotFound, "user not found")
+		}
+		return nil, err
+	}
+
+	return uc.userRepo.GetByID(ctx, userID)
+}
+
+// Пользователь с текущей загрузкой и лимитом ревью
+func (uc *UserUseCase) GetUser(ctx context.Context, userID string) (*entities.User, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err == repositories.ErrUserNotFound {
+		return nil, errors.NewDomainError(errors.ErrNotFound, "user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Заменяет навыки пользователя, навыки приводятся к нижнему регистру без дублей
+func (uc *UserUseCase) SetSkills(ctx context.Context, userID string, skills []string) (*entities.User, error) {
+	if _, err := uc.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.SetSkills(ctx, userID, entities.NormalizeTags(skills)); err != nil {
+		return nil, err
+	}
+
+	return uc.userRepo.GetByID(ctx, userID)
+}
+
+// Устанавливает рабочие часы пользователя (nil - без ограничений)
+func (uc *UserUseCase) SetWorkSchedule(ctx context.Context, userID string, schedule *entities.WorkSchedule) (*entities.User, error) {
+	if schedule != nil {
+		if err := schedule.Validate(); err != nil {
+			return nil, errors.NewDomainError(errors.ErrInvalidRequest, err.Error())
+		}
+	}
+
+	if err := uc.userRepo.SetWorkSchedule(ctx, userID, schedule); err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, errors.NewDomainError(errors.ErrNotFound, "user not found")
+		}
+		return nil, err
+	}
+
+	return uc.userRepo.GetByID(ctx, userID)
+}
+
+// Планирует период отсутствия: пока он действует, пользователь не назначается ревьювером
+func (uc *UserUseCase) AddOutOfOffice(ctx context.Context, userID string, startsAt, endsAt time.Time, reason string) (*entities.OutOfOfficePeriod, error) {
+	if startsAt.IsZero() || endsAt.IsZero() {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "starts_at and ends_at are required")
+	}
+	if !endsAt.After(startsAt) {
+		return nil, errors.NewDomainError(errors.ErrInvalidRequest, "ends_at must be after starts_at")
+	}
+
+	if _, err := uc.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	period := &entities.OutOfOfficePeriod{
+		UserID:   userID,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Reason:   reason,
+	}
+	if err := uc.userRepo.AddOutOfOffice(ctx, period); err != nil {
+		return nil, err
+	}
+
+	return period, nil
+}
+
+func (uc *UserUseCase) GetOutOfOffice(ctx context.Context, userID string) ([]*entities.OutOfOfficePeriod, error) {
+	if _, err := uc.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return uc.userRepo.GetOutOfOffice(ctx, userID)
+}
+
+func (uc *UserUseCase) DeleteOutOfOffice(ctx context.Context, userID string, periodID int64) error {
+	err := uc.userRepo.DeleteOutOfOffice(ctx, userID, periodID)
+	if err == repositories.ErrOutOfOfficeNotFound {
+		return errors.NewDomainError(errors.ErrNotFound, "out of office period not found")
+	}
+	return err
+}
+
+// Переводит ошибки уникальности пользователя в доменные
+func userError(err error) error {
+	switch err {
+	case repositories.ErrUserAlreadyExists:
+		return errors.NewDomainError(errors.ErrUserExists, "user_id already exists")
+	case repositories.ErrUsernameTaken:
+		return errors.NewDomainError(errors.ErrUsernameTaken, "username already taken")
+	case repositories.ErrUserNotFound:
+		return errors.NewDomainError(errors.ErrNotFound, "user not found")
+	}
+	return err
 }
@@ -0,0 +1,19 @@
+package entities
+
+// ReviewerStrategy определяет, каким способом выбираются ревьюверы среди подходящих кандидатов
+type ReviewerStrategy string
+
+const (
+	StrategyRandom           ReviewerStrategy = "random"             // случайный выбор
+	StrategyRoundRobin       ReviewerStrategy = "round_robin"        // по кругу, курсор хранится для каждой команды
+	StrategyLeastOpenReviews ReviewerStrategy = "least_open_reviews" // наименее загруженные открытыми ревью
+)
+
+func (s ReviewerStrategy) IsValid() bool {
+	switch s {
+	case StrategyRandom, StrategyRoundRobin, StrategyLeastOpenReviews:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,71 @@
+package entities
+
+import "time"
+
+// AssignmentAction - операция, при которой принималось решение о назначении
+type AssignmentAction string
+
+const (
+	ActionCreate    AssignmentAction = "create"    // назначение при создании PR
+	ActionReassign  AssignmentAction = "reassign"  // замена ревьювера
+	ActionEscalate  AssignmentAction = "escalate"  // тимлид добавлен из-за просроченного ревью
+	ActionReady     AssignmentAction = "ready"     // назначение, когда PR вышел из черновика или открыт заново без ревьюверов
+	ActionAdd       AssignmentAction = "add"       // ревьювер добавлен вручную
+	ActionRemove    AssignmentAction = "remove"    // ревьювер снят вручную без замены
+	ActionRebalance AssignmentAction = "rebalance" // ревью перенесено на менее загруженного участника команды
+	ActionResize    AssignmentAction = "resize"    // PR вырос, и политика требует для него больше ревьюверов
+)
+
+// CandidateSource - откуда кандидат попал в рассмотрение
+type CandidateSource string
+
+const (
+	SourceOwnerUser    CandidateSource = "owner_user"    // указан владельцем измененного пути
+	SourceOwnerTeam    CandidateSource = "owner_team"    // участник команды-владельца пути
+	SourceAuthorTeam   CandidateSource = "author_team"   // участник команды автора
+	SourceFallbackTeam CandidateSource = "fallback_team" // участник резервной команды
+	SourceTeamLead     CandidateSource = "team_lead"     // тимлид при эскалации просроченного ревью
+	SourceManual       CandidateSource = "manual"        // указан вручную
+	SourceReviewerTeam CandidateSource = "reviewer_team" // участник команды ревьювера при перераспределении нагрузки
+)
+
+// ExclusionReason - почему кандидат не был назначен
+type ExclusionReason string
+
+const (
+	ExcludedAuthor          ExclusionReason = "author"
+	ExcludedAlreadyAssigned ExclusionReason = "already_assigned"
+	ExcludedInactive        ExclusionReason = "inactive"
+	ExcludedOutOfOffice     ExclusionReason = "out_of_office"
+	ExcludedAtCapacity      ExclusionReason = "at_capacity"
+	ExcludedOutranked       ExclusionReason = "outranked" // подходил, но стратегия выбрала других
+	ExcludedRole            ExclusionReason = "role"      // роль в команде ниже требуемой политикой
+	ExcludedNoSkill         ExclusionReason = "no_skill"  // нет навыка по меткам PR для места под навык
+)
+
+// CandidateDecision - итог рассмотрения одного кандидата
+type CandidateDecision struct {
+	UserID   string          `json:"user_id"`
+	TeamName string          `json:"team_name,omitempty"`
+	Source   CandidateSource `json:"source"`
+	Rank     int             `json:"rank,omitempty"` // место в ранжировании стратегии внутри команды (с 1)
+	Selected bool            `json:"selected"`
+	// Для назначенного - правило выбора (например "least_open_reviews+skill_match"), иначе причина исключения
+	Rule     string          `json:"rule,omitempty"`
+	Excluded ExclusionReason `json:"excluded,omitempty"`
+}
+
+// AssignmentDecision - запись о том, кого и почему назначили ревьювером
+type AssignmentDecision struct {
+	ID             int64               `json:"id"`
+	PullRequestID  string              `json:"pull_request_id"`
+	Action         AssignmentAction    `json:"action"`
+	Strategy       ReviewerStrategy    `json:"strategy"`
+	ReplacedUserID string              `json:"replaced_user_id,omitempty"` // для замены и ручного снятия - снятый ревьювер
+	Candidates     []CandidateDecision `json:"candidates"`
+	CreatedAt      time.Time           `json:"created_at"`
+
+	// Требование политики к роли, которое не удалось выполнить: PR создан с теми, кто нашелся
+	MissingRole          TeamRole `json:"missing_role,omitempty"`
+	MissingRoleReviewers int      `json:"missing_role_reviewers,omitempty"`
+}
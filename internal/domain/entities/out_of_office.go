@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+// OutOfOfficePeriod - запланированное отсутствие пользователя (отпуск, больничный).
+// Пока период действует, пользователь не назначается ревьювером, is_active при этом не меняется
+type OutOfOfficePeriod struct {
+	ID       int64     `json:"id"`
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"` // не включительно
+	Reason   string    `json:"reason,omitempty"`
+}
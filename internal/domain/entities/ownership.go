@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// OwnershipRule - правило владения в стиле CODEOWNERS: шаблон пути и его владельцы.
+// Если пути подходят несколько правил, действует последнее (с наибольшей позицией)
+type OwnershipRule struct {
+	Position   int      `json:"position"`
+	Pattern    string   `json:"pattern"`
+	OwnerTeams []string `json:"owner_teams"`
+	OwnerUsers []string `json:"owner_users"`
+
+	re *regexp.Regexp // скомпилированный Pattern
+}
+
+// Compile проверяет шаблон и компилирует его один раз для всех последующих Matches.
+// Как и CODEOWNERS, не поддерживает отрицание "!", экранирование "\" и диапазоны "[ ]"
+func (r *OwnershipRule) Compile() error {
+	if strings.HasPrefix(r.Pattern, "!") || strings.ContainsAny(r.Pattern, "\\[]") {
+		return fmt.Errorf("unsupported pattern %q: negation, escapes and character ranges are not allowed", r.Pattern)
+	}
+
+	re, err := regexp.Compile(patternToRegexp(r.Pattern))
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", r.Pattern, err)
+	}
+	r.re = re
+	return nil
+}
+
+// Matches проверяет путь по правилам шаблонов CODEOWNERS:
+//   - "/" в начале или в середине шаблона привязывает его к корню репозитория,
+//     иначе шаблон совпадает на любой глубине;
+//   - "*" и "?" не выходят за пределы одного сегмента, "**" совпадает с любым числом сегментов;
+//   - шаблон, совпавший с каталогом, распространяется на все файлы внутри него
+//     (кроме шаблонов вида "docs/*", которые не затрагивают вложенные каталоги).
+//
+// Некомпилированное правило компилируется при первом вызове; неподдерживаемый шаблон не совпадает ни с чем
+func (r *OwnershipRule) Matches(path string) bool {
+	if r.re == nil && r.Compile() != nil {
+		return false
+	}
+	return r.re.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+func patternToRegexp(pattern string) string {
+	body := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(body, "/")
+	body = strings.TrimPrefix(body, "/")
+
+	var b strings.Builder
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; c {
+		case '*':
+			if i+1 < len(body) && body[i+1] == '*' {
+				i++
+				// "**/" совпадает и с пустым префиксом
+				if i+1 < len(body) && body[i+1] == '/' {
+					i++
+					b.WriteString("(.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	prefix := "^"
+	if !anchored {
+		prefix = "^(.*/)?"
+	}
+	suffix := "(/.*)?$"
+	if strings.HasSuffix(body, "/*") && !strings.HasSuffix(body, "**") {
+		suffix = "$"
+	}
+	return prefix + b.String() + suffix
+}
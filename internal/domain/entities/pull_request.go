@@ -1,22 +1,145 @@
 package entities
 
-import "time"
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
 
 type PullRequestStatus string
 
 const (
+	StatusDraft  PullRequestStatus = "DRAFT" // ревьюверы не назначаются, пока PR не готов
 	StatusOpen   PullRequestStatus = "OPEN"
 	StatusMerged PullRequestStatus = "MERGED"
+	StatusClosed PullRequestStatus = "CLOSED" // закрыт без мержа, можно открыть заново
 )
 
+func (s PullRequestStatus) IsValid() bool {
+	switch s {
+	case StatusDraft, StatusOpen, StatusMerged, StatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Разрешенные переходы жизненного цикла PR; MERGED - конечный статус
+var statusTransitions = map[PullRequestStatus][]PullRequestStatus{
+	StatusDraft:  {StatusOpen, StatusClosed},
+	StatusOpen:   {StatusMerged, StatusClosed},
+	StatusClosed: {StatusOpen},
+}
+
+// TransitionError - переход между статусами, не разрешенный жизненным циклом PR
+type TransitionError struct {
+	From PullRequestStatus
+	To   PullRequestStatus
+}
+
+func (e TransitionError) Error() string {
+	return fmt.Sprintf("cannot move pull request from %s to %s", e.From, e.To)
+}
+
 type PullRequest struct {
 	ID                string            `json:"pull_request_id"`
 	Name              string            `json:"pull_request_name"`
 	AuthorID          string            `json:"author_id"`
+	TeamName          string            `json:"team_name,omitempty"` // основная команда автора при создании PR ("" - без команды: действует текущая основная команда автора)
 	AssignedReviewers []string          `json:"assigned_reviewers"`
+	FallbackReviewers []string          `json:"fallback_reviewers,omitempty"` // подмножество назначенных, взятых из резервных команд
+	PinnedReviewers   []string          `json:"pinned_reviewers,omitempty"`   // подмножество назначенных, закрепленных вручную
+	Labels            []string          `json:"labels,omitempty"`             // метки PR для подбора ревьюверов по навыкам
+	Paths             []string          `json:"paths,omitempty"`              // измененные файлы для подбора владельцев
 	Status            PullRequestStatus `json:"status"`
+	NeedsReviewer     bool              `json:"needs_reviewer"` // открытый PR с неактивным ревьювером или без ревьюверов с ролью, требуемой политикой
 	CreatedAt         *time.Time        `json:"createdAt"`
 	MergedAt          *time.Time        `json:"mergedAt"`
+	UpdatedAt         *time.Time        `json:"updatedAt"` // время последнего изменения, с ним же пишется assigned_at новых ревьюверов
+
+	PullRequestMetadata
+}
+
+// PullRequestMetadata - необязательные сведения о PR из системы контроля версий
+type PullRequestMetadata struct {
+	Description  string `json:"description,omitempty"`
+	Repository   string `json:"repository,omitempty"` // slug репозитория, например org/service
+	URL          string `json:"url,omitempty"`        // ссылка на PR во внешней системе
+	SourceBranch string `json:"source_branch,omitempty"`
+	TargetBranch string `json:"target_branch,omitempty"`
+	LinesAdded   int    `json:"lines_added"`
+	LinesRemoved int    `json:"lines_removed"`
+}
+
+// Size - размер PR в измененных строках
+func (m PullRequestMetadata) Size() int {
+	return m.LinesAdded + m.LinesRemoved
+}
+
+// Validate проверяет ссылку и размер PR
+func (m PullRequestMetadata) Validate() error {
+	if m.LinesAdded < 0 || m.LinesRemoved < 0 {
+		return fmt.Errorf("lines_added and lines_removed must not be negative")
+	}
+	if m.URL != "" {
+		parsed, err := url.Parse(m.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("url must be an absolute http(s) URL")
+		}
+	}
+	return nil
+}
+
+// TransitionTo переводит PR в статус to, если жизненный цикл это разрешает
+func (pr *PullRequest) TransitionTo(to PullRequestStatus) error {
+	for _, allowed := range statusTransitions[pr.Status] {
+		if allowed == to {
+			pr.Status = to
+			return nil
+		}
+	}
+	return TransitionError{From: pr.Status, To: to}
+}
+
+// MarkReady переводит черновик в OPEN
+func (pr *PullRequest) MarkReady() error {
+	if pr.Status != StatusDraft {
+		return TransitionError{From: pr.Status, To: StatusOpen}
+	}
+	return pr.TransitionTo(StatusOpen)
+}
+
+// Reopen снова открывает закрытый PR
+func (pr *PullRequest) Reopen() error {
+	if pr.Status != StatusClosed {
+		return TransitionError{From: pr.Status, To: StatusOpen}
+	}
+	return pr.TransitionTo(StatusOpen)
+}
+
+// PullRequestListFilter - страница списка PR, упорядоченного по времени создания.
+// Периоды задаются полуинтервалами [from, to), nil - без границы
+type PullRequestListFilter struct {
+	Status      PullRequestStatus // "" - любой статус
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string // команда PR
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	NoReviewers bool // только PR без назначенных ревьюверов
+	Descending  bool // сначала новые
+	After       *PullRequestPageKey
+	Limit       int
+}
+
+// PullRequestPageKey - позиция последнего PR предыдущей страницы.
+// id различает PR, созданные в один момент, поэтому вставки не сдвигают страницы
+type PullRequestPageKey struct {
+	CreatedAt  time.Time `json:"created_at"`
+	ID         string    `json:"id"`
+	Descending bool      `json:"desc"` // порядок, в котором выдана страница
 }
 
 type PullRequestShort struct {
@@ -30,8 +153,23 @@ type PullRequestShort struct {
 
 // PRStatusStats содержит статистику по статусам PR
 type PRStatusStats struct {
+	Draft  int `json:"draft"`
 	Open   int `json:"open"`
 	Merged int `json:"merged"`
+	Closed int `json:"closed"`
+}
+
+func (s *PRStatusStats) Add(status PullRequestStatus) {
+	switch status {
+	case StatusDraft:
+		s.Draft++
+	case StatusOpen:
+		s.Open++
+	case StatusMerged:
+		s.Merged++
+	case StatusClosed:
+		s.Closed++
+	}
 }
 
 // UserPRStats содержит статистику по PR для пользователя
@@ -0,0 +1,70 @@
+package entities
+
+import "time"
+
+// ReviewState - решение ревьювера по PR
+type ReviewState string
+
+const (
+	ReviewApproved         ReviewState = "APPROVED"
+	ReviewChangesRequested ReviewState = "CHANGES_REQUESTED" // блокирует мерж, пока ревьювер не одобрит PR
+	ReviewCommented        ReviewState = "COMMENTED"         // не меняет предыдущее решение ревьювера
+	ReviewDismissed        ReviewState = "DISMISSED"         // отменяет запрос изменений ревьювера, записывается через dismissReview
+)
+
+func (s ReviewState) IsValid() bool {
+	switch s {
+	case ReviewApproved, ReviewChangesRequested, ReviewCommented, ReviewDismissed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Review - одно ревью в истории PR
+type Review struct {
+	ID            int64       `json:"id"`
+	PullRequestID string      `json:"pull_request_id"`
+	ReviewerID    string      `json:"reviewer_id"`
+	State         ReviewState `json:"state"`
+	Comment       string      `json:"comment,omitempty"`
+	DismissedBy   string      `json:"dismissed_by,omitempty"` // кто отменил запрос изменений (только для DISMISSED)
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+// ReviewStatus - действующие решения ревьюверов
+type ReviewStatus struct {
+	ApprovedBy         []string `json:"approved_by"`
+	ChangesRequestedBy []string `json:"changes_requested_by"`
+}
+
+// SummarizeReviews учитывает последнее APPROVED, CHANGES_REQUESTED или DISMISSED каждого ревьювера.
+// Одобрения считаются только от назначенных reviewers, а запрос изменений действует и после снятия
+// ревьювера с PR, пока его не отменят через DISMISSED. reviews должны быть упорядочены по времени
+func SummarizeReviews(reviews []*Review, reviewers []string) ReviewStatus {
+	latest := make(map[string]ReviewState)
+	var order []string
+	for _, review := range reviews {
+		if review.State == ReviewCommented {
+			continue
+		}
+		if _, seen := latest[review.ReviewerID]; !seen {
+			order = append(order, review.ReviewerID)
+		}
+		latest[review.ReviewerID] = review.State
+	}
+
+	status := ReviewStatus{ApprovedBy: []string{}, ChangesRequestedBy: []string{}}
+	for _, reviewerID := range reviewers {
+		if latest[reviewerID] == ReviewApproved {
+			status.ApprovedBy = append(status.ApprovedBy, reviewerID)
+		}
+	}
+	for _, reviewerID := range order {
+		if latest[reviewerID] == ReviewChangesRequested {
+			status.ChangesRequestedBy = append(status.ChangesRequestedBy, reviewerID)
+		}
+	}
+
+	return status
+}
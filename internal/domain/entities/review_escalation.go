@@ -0,0 +1,42 @@
+package entities
+
+import "time"
+
+// SLAAction - что делать с ревью, которое не выполнено в срок
+type SLAAction string
+
+const (
+	SLAActionReassign SLAAction = "reassign" // заменить ревьювера по правилам ReassignReviewer
+	SLAActionEscalate SLAAction = "escalate" // добавить в ревьюверы тимлида команды
+)
+
+func (a SLAAction) IsValid() bool {
+	switch a {
+	case SLAActionReassign, SLAActionEscalate:
+		return true
+	default:
+		return false
+	}
+}
+
+// OverdueReview - назначенное ревью открытого PR, просроченное по SLA команды PR
+type OverdueReview struct {
+	PullRequestID string
+	ReviewerID    string
+	TeamName      string
+	AssignedAt    time.Time
+}
+
+// ReviewEscalation - запись истории обработки просроченного ревью.
+// Каждое назначение (PR, ревьювер, assigned_at) обрабатывается один раз
+type ReviewEscalation struct {
+	ID            int64     `json:"id"`
+	PullRequestID string    `json:"pull_request_id"`
+	ReviewerID    string    `json:"reviewer_id"` // просрочивший ревью
+	TeamName      string    `json:"team_name"`
+	Action        SLAAction `json:"action"`
+	// Новый ревьювер или тимлид; пусто - назначить было некого
+	NewReviewerID string    `json:"new_reviewer_id,omitempty"`
+	AssignedAt    time.Time `json:"assigned_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// PendingReview - назначенное ревью открытого PR, которое ревьювер еще не начал
+// (не отправил ни одного ревью после назначения) и которое не закреплено
+type PendingReview struct {
+	PullRequestID string
+	ReviewerID    string
+	AssignedAt    time.Time
+}
+
+// ReviewMove - ревью, перенесенное при перераспределении нагрузки внутри команды
+type ReviewMove struct {
+	PullRequestID string `json:"pull_request_id"`
+	TeamName      string `json:"team_name"`
+	FromUserID    string `json:"from_user_id"`
+	ToUserID      string `json:"to_user_id"`
+}
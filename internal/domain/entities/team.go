@@ -1,8 +1,53 @@
 package entities
 
+import (
+	"slices"
+	"time"
+)
+
+// Лимит открытых ревью на участника, если команда не задала свой
+const DefaultMaxOpenReviews = 3
+
 type Team struct {
 	Name    string  `json:"team_name"`
 	Members []*User `json:"members"`
+
+	DefaultMaxOpenReviews int `json:"default_max_open_reviews"` // лимит для участников без персонального
+}
+
+// CountWithRole - сколько из userIDs состоят в команде с ролью не младше role
+func (t *Team) CountWithRole(userIDs []string, role TeamRole) int {
+	count := 0
+	for _, member := range t.Members {
+		if member.Role.AtLeast(role) && slices.Contains(userIDs, member.UserID) {
+			count++
+		}
+	}
+	return count
+}
+
+// TeamMembership - участие пользователя в одной из его команд
+type TeamMembership struct {
+	TeamName  string    `json:"team_name"`
+	Role      TeamRole  `json:"role"`
+	IsPrimary bool      `json:"is_primary"` // от имени основной команды пользователь создает PR
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+// TeamSummary - команда в списке команд: счетчики участников без их состава
+type TeamSummary struct {
+	TeamName              string `json:"team_name"`
+	DefaultMaxOpenReviews int    `json:"default_max_open_reviews"`
+	MemberCount           int    `json:"member_count"`
+	ActiveCount           int    `json:"active_count"`
+	BusyCount             int    `json:"busy_count"` // участники хотя бы с одним открытым ревью (User.IsBusy)
+}
+
+// TeamListFilter - страница списка команд по имени
+type TeamListFilter struct {
+	Prefix string // начало имени без учета регистра ("" - все команды)
+	After  string // имя последней команды предыдущей страницы
+	Limit  int
 }
 
 type TeamMember struct {
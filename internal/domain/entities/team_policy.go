@@ -0,0 +1,54 @@
+package entities
+
+// Значения политики, если команда не задала свою
+const (
+	DefaultRequiredReviewers = 2
+	DefaultMinApprovals      = 0
+)
+
+// TeamPolicy - правила назначения ревьюверов в команде
+type TeamPolicy struct {
+	TeamName          string           `json:"team_name"`
+	RequiredReviewers int              `json:"required_reviewers"`  // сколько ревьюверов назначать на PR
+	MinApprovals      int              `json:"min_approvals"`       // сколько одобрений нужно для мержа
+	Strategy          ReviewerStrategy `json:"strategy,omitempty"`  // пусто - стратегия развертывания
+	CrossTeamFallback bool             `json:"cross_team_fallback"` // добирать ревьюверов из других команд
+	FallbackTeams     []string         `json:"fallback_teams"`      // резервные команды в порядке приоритета
+
+	// Предпочитать ревьюверов, у которых сейчас рабочее время (если таких нет - любых доступных)
+	PreferWorkingHours bool `json:"prefer_working_hours"`
+
+	// SLA ревью в часах от назначения ревьювера (0 - не отслеживается)
+	ReviewSLAHours int       `json:"review_sla_hours"`
+	SLAAction      SLAAction `json:"sla_action"`             // что делать с просроченным ревью
+	TeamLeadID     string    `json:"team_lead_id,omitempty"` // кому эскалировать просроченные ревью
+
+	// Сколько ревьюверов PR должны быть участниками команды с ролью не младше RequiredRole (0 - без требования)
+	RequiredRole     TeamRole `json:"required_role,omitempty"`
+	MinRoleReviewers int      `json:"min_role_reviewers"`
+
+	// Перераспределять неначатые ревью между участниками по расписанию (REBALANCE_INTERVAL)
+	AutoRebalance bool `json:"auto_rebalance"`
+
+	// PR от LargePRLines измененных строк получают LargePRReviewers ревьюверов (0 - без порога)
+	LargePRLines     int `json:"large_pr_lines"`
+	LargePRReviewers int `json:"large_pr_reviewers"`
+}
+
+// ReviewersFor - сколько ревьюверов назначать на PR размером size строк
+func (p *TeamPolicy) ReviewersFor(size int) int {
+	if p.LargePRLines > 0 && size >= p.LargePRLines {
+		return max(p.RequiredReviewers, p.LargePRReviewers)
+	}
+	return p.RequiredReviewers
+}
+
+func DefaultTeamPolicy(teamName string) *TeamPolicy {
+	return &TeamPolicy{
+		TeamName:          teamName,
+		RequiredReviewers: DefaultRequiredReviewers,
+		MinApprovals:      DefaultMinApprovals,
+		FallbackTeams:     []string{},
+		SLAAction:         SLAActionReassign,
+	}
+}
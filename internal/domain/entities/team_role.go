@@ -0,0 +1,32 @@
+package entities
+
+// TeamRole - роль участника в команде. Роли упорядочены по старшинству: member < senior < lead
+type TeamRole string
+
+const (
+	RoleMember TeamRole = "member"
+	RoleSenior TeamRole = "senior"
+	RoleLead   TeamRole = "lead"
+)
+
+func (r TeamRole) IsValid() bool {
+	return r.rank() > 0
+}
+
+// AtLeast - роль не младше min
+func (r TeamRole) AtLeast(min TeamRole) bool {
+	return r.rank() >= min.rank()
+}
+
+func (r TeamRole) rank() int {
+	switch r {
+	case RoleMember:
+		return 1
+	case RoleSenior:
+		return 2
+	case RoleLead:
+		return 3
+	default:
+		return 0
+	}
+}
@@ -1,7 +1,78 @@
 package entities
 
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
 type User struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	IsActive bool   `json:"is_active"` // доступность: управляется администратором, назначение ревью его не меняет
+
+	Role     TeamRole `json:"role,omitempty"`      // роль в команде, заполняется только для участников команды
+	TeamName string   `json:"team_name,omitempty"` // основная команда, заполняется при чтении пользователя по ID
+
+	OpenReviews int `json:"open_reviews"` // вычисляемая загрузка: количество OPEN PR, где пользователь ревьювер
+
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"` // персональный лимит открытых ревью (nil - лимит команды)
+	ReviewCapacity int  `json:"review_capacity"`            // действующий лимит с учетом значения команды
+
+	Skills []string `json:"skills,omitempty"` // навыки (sql, k8s, frontend-a11y), сопоставляются с метками PR
+
+	Schedule *WorkSchedule `json:"work_schedule,omitempty"` // рабочие часы (nil - без ограничений)
+}
+
+// UserListFilter - страница списка пользователей
+type UserListFilter struct {
+	TeamName string // участники команды ("" - все пользователи)
+	IsActive *bool
+	Busy     *bool  // хотя бы одно открытое ревью (IsBusy)
+	Search   string // подстрока username без учета регистра
+	After    string // user_id последнего пользователя предыдущей страницы
+	Limit    int
+}
+
+// IsBusy - пользователь сейчас занят хотя бы одним открытым ревью
+func (u *User) IsBusy() bool {
+	return u.OpenReviews > 0
+}
+
+// HasCapacity - пользователь может взять еще одно ревью
+func (u *User) HasCapacity() bool {
+	return u.OpenReviews < u.ReviewCapacity
+}
+
+// InWorkingHours - у пользователя рабочее время в момент t (без расписания - всегда)
+func (u *User) InWorkingHours(t time.Time) bool {
+	return u.Schedule == nil || u.Schedule.Contains(t)
+}
+
+// MatchesAnySkill - у пользователя есть навык хотя бы по одной из меток
+func (u *User) MatchesAnySkill(labels []string) bool {
+	for _, skill := range u.Skills {
+		for _, label := range labels {
+			if skill == label {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NormalizeTags приводит навыки и метки к единому виду: нижний регистр, без пробелов по краям и повторов
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
 }
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// WorkSchedule - рабочие часы пользователя в его часовом поясе.
+// Если End раньше Start, рабочий интервал переходит через полночь
+type WorkSchedule struct {
+	Timezone string `json:"timezone"` // IANA, например Europe/Moscow
+	Start    string `json:"start"`    // начало рабочего дня, "09:00"
+	End      string `json:"end"`      // конец рабочего дня (не включительно), "18:00"
+}
+
+func (s *WorkSchedule) Validate() error {
+	if _, err := time.LoadLocation(s.Timezone); err != nil || s.Timezone == "" {
+		return fmt.Errorf("unknown timezone %q", s.Timezone)
+	}
+
+	start, err := parseClock(s.Start)
+	if err != nil {
+		return err
+	}
+	end, err := parseClock(s.End)
+	if err != nil {
+		return err
+	}
+	if start == end {
+		return fmt.Errorf("start and end of working hours must differ")
+	}
+
+	return nil
+}
+
+// Contains - момент t попадает в рабочие часы
+func (s *WorkSchedule) Contains(t time.Time) bool {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false
+	}
+	start, err := parseClock(s.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(s.End)
+	if err != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// Минуты от полуночи для времени в формате "15:04"
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -10,8 +10,16 @@ const (
 	ErrPRMerged    ErrorCode = "PR_MERGED"
 	ErrTeamExists  ErrorCode = "TEAM_EXISTS"
 
-	ErrUserInAnotherTeam ErrorCode = "USER_IN_ANOTHER_TEAM" // Если пытаемся привязать
-	// к новой команде пользователя, который находится в другой команде
+	ErrInvalidRequest ErrorCode = "INVALID_REQUEST" // Некорректные параметры запроса (например, неизвестная стратегия)
+
+	ErrMergeBlocked ErrorCode = "MERGE_BLOCKED" // Не хватает одобрений или есть запрос изменений
+
+	ErrInvalidTransition ErrorCode = "INVALID_TRANSITION" // Переход или операция не разрешены в текущем статусе PR
+
+	ErrTeamHasOpenPRs ErrorCode = "TEAM_HAS_OPEN_PRS" // Удаление команды, у авторов которой есть открытые PR, без force
+
+	ErrUserExists    ErrorCode = "USER_EXISTS"    // Пользователь с таким user_id уже есть
+	ErrUsernameTaken ErrorCode = "USERNAME_TAKEN" // Имя пользователя занято другим пользователем
 )
 
 type DomainError struct {
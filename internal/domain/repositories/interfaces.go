@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"go-project/internal/domain/entities"
+	"time"
 )
 
 var (
@@ -13,11 +14,13 @@ var (
 	ErrTeamMemberNotFound       = errors.New("team member not found")
 	ErrPullRequestNotFound      = errors.New("pull request not found")
 	ErrUserAlreadyExists        = errors.New("user already exists")
+	ErrUsernameTaken            = errors.New("username already taken")
 	ErrTeamAlreadyExists        = errors.New("team already exists")
 	ErrPullRequestAlreadyExists = errors.New("pull request already exists")
 	ErrInvalidData              = errors.New("invalid data")
 	ErrDatabaseOperation        = errors.New("database operation failed")
 	ErrConstraintViolation      = errors.New("constraint violation")
+	ErrOutOfOfficeNotFound      = errors.New("out of office period not found")
 )
 
 type PullRequestRepository interface {
@@ -25,24 +28,94 @@ type PullRequestRepository interface {
 	GetByID(ctx context.Context, id string) (*entities.PullRequest, error)
 	GetByAuthorID(ctx context.Context, authorID string) ([]entities.PullRequestShort, error)
 	GetByReviewerID(ctx context.Context, reviewerID string) ([]entities.PullRequestShort, error)
+	// Полные PR (с ревьюверами и метками), где ревьювером назначен кто-то из reviewerIDs
+	GetByReviewers(ctx context.Context, reviewerIDs []string) ([]*entities.PullRequest, error)
+	// Страница полных PR по фильтру в порядке (created_at, id)
+	List(ctx context.Context, filter entities.PullRequestListFilter) ([]*entities.PullRequest, error)
 	Update(ctx context.Context, pr *entities.PullRequest) error
+	// Перезапускает отсчет SLA назначенных ревьюверов (например, при повторном открытии PR)
+	ResetAssignedAt(ctx context.Context, prID string, at time.Time) error
 	Delete(ctx context.Context, id string) error
+	// Журнал решений о назначении ревьюверов
+	SaveAssignmentDecision(ctx context.Context, decision *entities.AssignmentDecision) error
+	GetAssignmentDecisions(ctx context.Context, prID string) ([]*entities.AssignmentDecision, error)
+	// Назначения в OPEN PR, просроченные на момент now по SLA команды автора, еще не обработанные
+	// и без ревью от назначенного
+	GetOverdueReviews(ctx context.Context, now time.Time) ([]*entities.OverdueReview, error)
+	// История обработки просроченных ревью
+	SaveEscalation(ctx context.Context, escalation *entities.ReviewEscalation) error
+	GetEscalations(ctx context.Context, teamName string) ([]*entities.ReviewEscalation, error)
+	// История ревью PR в порядке отправки
+	AddReview(ctx context.Context, review *entities.Review) error
+	GetReviews(ctx context.Context, prID string) ([]*entities.Review, error)
+	// Неначатые и незакрепленные ревью reviewerIDs в OPEN PR, сначала самые новые назначения
+	GetPendingReviews(ctx context.Context, reviewerIDs []string) ([]*entities.PendingReview, error)
+	// ID OPEN PR и черновиков, для авторов которых teamName - основная команда
+	GetOpenIDsByTeam(ctx context.Context, teamName string) ([]string, error)
 }
 
 type TeamRepository interface {
 	Create(ctx context.Context, team *entities.Team) error
 	GetByName(ctx context.Context, name string) (*entities.Team, error)
+	// Основная команда пользователя: выбранная явно, иначе первая, в которую он вступил
 	GetByUserID(ctx context.Context, userID string) (*entities.Team, error)
+	// Все команды пользователя, основная первой
+	GetMemberships(ctx context.Context, userID string) ([]*entities.TeamMembership, error)
 	Update(ctx context.Context, team *entities.Team) error
+	// Блокирует строку команды до конца транзакции из контекста: PR этой команды, создаваемые
+	// параллельно, ждут ее завершения (ErrTeamNotFound, если команды нет)
+	Lock(ctx context.Context, teamName string) error
 	Delete(ctx context.Context, teamName string) error
-	AddMember(ctx context.Context, teamName, userID string, isActive bool) error
+	// Переименовывает команду вместе со всеми ссылками на нее
+	Rename(ctx context.Context, teamName, newName string) error
+	AddMember(ctx context.Context, teamName, userID string, role entities.TeamRole) error
 	RemoveMember(ctx context.Context, teamName, userID string) error
+	// Курсор round-robin: последний назначенный в команде ревьювер ("" если назначений не было)
+	GetReviewerCursor(ctx context.Context, teamName string) (string, error)
+	SaveReviewerCursor(ctx context.Context, teamName, userID string) error
+	// Политика ревью команды (значения по умолчанию, если команда ее не задавала)
+	GetPolicy(ctx context.Context, teamName string) (*entities.TeamPolicy, error)
+	SavePolicy(ctx context.Context, policy *entities.TeamPolicy) error
+	// Команды по возрастанию имени с filter.After (не включая), не больше filter.Limit
+	List(ctx context.Context, filter entities.TeamListFilter) ([]*entities.TeamSummary, error)
+	// Команды, включившие в политике перераспределение нагрузки по расписанию
+	GetAutoRebalanceTeams(ctx context.Context) ([]string, error)
 }
 
 type UserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
 	GetByID(ctx context.Context, id string) (*entities.User, error)
+	// Пользователи по возрастанию user_id с filter.After (не включая), не больше filter.Limit
+	List(ctx context.Context, filter entities.UserListFilter) ([]*entities.User, error)
 	Update(ctx context.Context, user *entities.User) error
 	SetActive(ctx context.Context, userID string, isActive bool) error
+	SetActiveMany(ctx context.Context, userIDs []string, isActive bool) error
+	// "" сбрасывает выбор, и основной считается первая команда пользователя
+	SetPrimaryTeam(ctx context.Context, userID, teamName string) error
+	// nil снимает персональный лимит, и действует лимит команды
+	SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error
+	// Заменяет навыки пользователя
+	SetSkills(ctx context.Context, userID string, skills []string) error
+	// nil снимает ограничение по рабочим часам
+	SetWorkSchedule(ctx context.Context, userID string, schedule *entities.WorkSchedule) error
+	// Периоды отсутствия пользователя, отсортированные по началу
+	AddOutOfOffice(ctx context.Context, period *entities.OutOfOfficePeriod) error
+	GetOutOfOffice(ctx context.Context, userID string) ([]*entities.OutOfOfficePeriod, error)
+	DeleteOutOfOffice(ctx context.Context, userID string, periodID int64) error
+	// ID пользователей, у которых в момент at действует период отсутствия
+	GetOutOfOfficeUserIDs(ctx context.Context, at time.Time) ([]string, error)
 	Delete(ctx context.Context, userID string) error
 }
+
+// TxManager выполняет вызовы нескольких репозиториев в одной транзакции:
+// репозитории, получившие контекст из fn, работают внутри нее
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type OwnershipRuleRepository interface {
+	// Заменяет все правила (импорт файла CODEOWNERS)
+	ReplaceAll(ctx context.Context, rules []*entities.OwnershipRule) error
+	// Правила в порядке позиций
+	GetAll(ctx context.Context) ([]*entities.OwnershipRule, error)
+}
@@ -0,0 +1,6 @@
+-- Курсор round-robin для выбора ревьюверов (последний назначенный в команде)
+CREATE TABLE IF NOT EXISTS team_reviewer_cursors (
+    team_name VARCHAR(100) PRIMARY KEY REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    last_user_id VARCHAR(50) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,4 @@
+-- Лимит открытых ревью: персональный у пользователя (NULL - берется из команды) и по умолчанию у команды
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INT CHECK (max_open_reviews > 0);
+
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS default_max_open_reviews INT NOT NULL DEFAULT 3 CHECK (default_max_open_reviews > 0);
@@ -0,0 +1,10 @@
+-- Политика ревью команды. Если строки нет, действуют значения по умолчанию
+CREATE TABLE IF NOT EXISTS team_policies (
+    team_name VARCHAR(100) PRIMARY KEY REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    required_reviewers INT NOT NULL DEFAULT 2 CHECK (required_reviewers > 0),
+    min_approvals INT NOT NULL DEFAULT 0 CHECK (min_approvals >= 0),
+    strategy VARCHAR(50), -- NULL - стратегия развертывания
+    cross_team_fallback BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (min_approvals <= required_reviewers)
+);
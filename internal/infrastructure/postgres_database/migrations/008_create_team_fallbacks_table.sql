@@ -0,0 +1,11 @@
+-- Резервные команды, из которых добираются ревьюверы (меньший priority - раньше)
+CREATE TABLE IF NOT EXISTS team_fallbacks (
+    team_name VARCHAR(100) REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    fallback_team_name VARCHAR(100) REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    priority INT NOT NULL,
+    PRIMARY KEY (team_name, fallback_team_name),
+    CHECK (team_name <> fallback_team_name)
+);
+
+-- Ревьювер назначен из резервной команды
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS is_fallback BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,8 @@
+-- Правила владения путями (CODEOWNERS). При совпадении нескольких правил действует правило с большей позицией
+CREATE TABLE IF NOT EXISTS ownership_rules (
+    position INT PRIMARY KEY,
+    pattern VARCHAR(500) NOT NULL,
+    owner_teams TEXT[] NOT NULL DEFAULT '{}',
+    owner_users TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,13 @@
+-- Навыки пользователей
+CREATE TABLE IF NOT EXISTS user_skills (
+    user_id VARCHAR(50) REFERENCES users(user_id) ON DELETE CASCADE,
+    skill VARCHAR(50) NOT NULL,
+    PRIMARY KEY (user_id, skill)
+);
+
+-- Метки pull request'ов
+CREATE TABLE IF NOT EXISTS pull_request_labels (
+    pull_request_id VARCHAR(50) REFERENCES pull_requests(id) ON DELETE CASCADE,
+    label VARCHAR(50) NOT NULL,
+    PRIMARY KEY (pull_request_id, label)
+);
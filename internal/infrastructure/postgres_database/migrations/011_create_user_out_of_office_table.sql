@@ -0,0 +1,12 @@
+-- Запланированные периоды отсутствия пользователей
+CREATE TABLE IF NOT EXISTS user_out_of_office (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(50) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_out_of_office_user_id ON user_out_of_office(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_out_of_office_period ON user_out_of_office(starts_at, ends_at);
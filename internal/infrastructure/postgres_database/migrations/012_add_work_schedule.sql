@@ -0,0 +1,7 @@
+-- Рабочие часы пользователя в его часовом поясе (NULL - без ограничений)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS work_start VARCHAR(5);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS work_end VARCHAR(5);
+
+-- Предпочитать ревьюверов, у которых сейчас рабочее время
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS prefer_working_hours BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,12 @@
+-- Журнал решений о назначении ревьюверов: кто рассматривался и почему выбран или исключен
+CREATE TABLE IF NOT EXISTS assignment_decisions (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    strategy VARCHAR(50) NOT NULL,
+    replaced_user_id VARCHAR(50),
+    candidates JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_decisions_pr ON assignment_decisions(pull_request_id);
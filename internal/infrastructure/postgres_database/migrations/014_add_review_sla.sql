@@ -0,0 +1,23 @@
+-- SLA ревью команды: через сколько часов после назначения ревью считается просроченным (0 - не отслеживается)
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS review_sla_hours INT NOT NULL DEFAULT 0 CHECK (review_sla_hours >= 0);
+-- reassign - заменить ревьювера, escalate - добавить в ревьюверы тимлида
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS sla_action VARCHAR(20) NOT NULL DEFAULT 'reassign';
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS team_lead_id VARCHAR(50) REFERENCES users(user_id) ON DELETE SET NULL;
+
+-- Поиск просроченных назначений
+CREATE INDEX IF NOT EXISTS idx_pull_request_reviewers_assigned_at ON pull_request_reviewers(assigned_at);
+
+-- История обработки просроченных ревью. Одно назначение (PR, ревьювер, assigned_at) обрабатывается один раз
+CREATE TABLE IF NOT EXISTS review_escalations (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(50) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    team_name VARCHAR(100) NOT NULL REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    new_reviewer_id VARCHAR(50) REFERENCES users(user_id) ON DELETE SET NULL,
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (pull_request_id, reviewer_id, assigned_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_escalations_team ON review_escalations(team_name, created_at);
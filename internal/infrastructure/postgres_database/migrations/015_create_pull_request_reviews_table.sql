@@ -0,0 +1,11 @@
+-- История ревью: решения назначенных ревьюверов по PR
+CREATE TABLE IF NOT EXISTS pull_request_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(50) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    state VARCHAR(20) NOT NULL CHECK (state IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_reviews_pr ON pull_request_reviews(pull_request_id, id);
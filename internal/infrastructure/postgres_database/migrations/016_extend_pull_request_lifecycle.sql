@@ -0,0 +1,6 @@
+-- Черновики (ревьюверы не назначаются) и PR, закрытые без мержа
+ALTER TYPE pull_request_status ADD VALUE IF NOT EXISTS 'DRAFT';
+ALTER TYPE pull_request_status ADD VALUE IF NOT EXISTS 'CLOSED';
+
+-- Измененные файлы PR: по ним назначаются владельцы, когда черновик становится готов к ревью
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS paths TEXT[] NOT NULL DEFAULT '{}';
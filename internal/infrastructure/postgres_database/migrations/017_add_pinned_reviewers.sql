@@ -0,0 +1,2 @@
+-- Закрепленные вручную ревьюверы: автоматическая замена и перераспределение их не трогают
+ALTER TABLE pull_request_reviewers ADD COLUMN IF NOT EXISTS is_pinned BOOLEAN NOT NULL DEFAULT FALSE;
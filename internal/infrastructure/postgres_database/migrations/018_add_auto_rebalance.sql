@@ -0,0 +1,2 @@
+-- Периодически перераспределять неначатые ревью между участниками команды
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS auto_rebalance BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+-- Роль участника в команде: member, senior или lead
+ALTER TABLE team_members ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'member';
+
+-- Политика может требовать минимум ревьюверов из команды автора с ролью не ниже required_role
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS required_role VARCHAR(20);
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS min_role_reviewers INT NOT NULL DEFAULT 0 CHECK (min_role_reviewers >= 0);
@@ -0,0 +1,5 @@
+-- Пользователь может состоять в нескольких командах; основная команда определяет,
+-- от имени какой команды он создает PR. Если не выбрана - первая, в которую он вступил
+ALTER TABLE users ADD COLUMN IF NOT EXISTS primary_team VARCHAR(100) REFERENCES teams(name) ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_team_members_user_id ON team_members(user_id);
@@ -0,0 +1,12 @@
+-- Переименование команды переносит ее участников (остальные ссылки на teams уже с ON UPDATE CASCADE)
+DO $$ 
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint 
+        WHERE conname = 'team_members_team_name_fkey' AND confupdtype = 'c'
+    ) THEN
+        ALTER TABLE team_members DROP CONSTRAINT IF EXISTS team_members_team_name_fkey;
+        ALTER TABLE team_members ADD CONSTRAINT team_members_team_name_fkey
+            FOREIGN KEY (team_name) REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE;
+    END IF;
+END $$;
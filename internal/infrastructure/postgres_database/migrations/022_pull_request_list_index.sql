@@ -0,0 +1,13 @@
+-- Список PR постранично упорядочен по (created_at, id), поэтому время создания обязательно
+DO $$ 
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns 
+        WHERE table_name = 'pull_requests' AND column_name = 'created_at' AND is_nullable = 'YES'
+    ) THEN
+        UPDATE pull_requests SET created_at = COALESCE(updated_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+        ALTER TABLE pull_requests ALTER COLUMN created_at SET NOT NULL;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_at_id ON pull_requests(created_at, id);
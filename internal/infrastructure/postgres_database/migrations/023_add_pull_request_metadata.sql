@@ -0,0 +1,12 @@
+-- Сведения о PR из системы контроля версий
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS repository VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS source_branch VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS target_branch VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS lines_added INTEGER NOT NULL DEFAULT 0 CHECK (lines_added >= 0);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS lines_removed INTEGER NOT NULL DEFAULT 0 CHECK (lines_removed >= 0);
+
+-- Большим PR политика команды может назначать больше ревьюверов (0 - без порога)
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS large_pr_lines INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE team_policies ADD COLUMN IF NOT EXISTS large_pr_reviewers INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,15 @@
+-- Отмена запроса изменений: запись DISMISSED в истории ревью с автором отмены
+ALTER TABLE pull_request_reviews ADD COLUMN IF NOT EXISTS dismissed_by VARCHAR(50) REFERENCES users(user_id) ON DELETE SET NULL;
+
+DO $$ 
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint 
+        WHERE conname = 'pull_request_reviews_state_check'
+            AND pg_get_constraintdef(oid) LIKE '%DISMISSED%'
+    ) THEN
+        ALTER TABLE pull_request_reviews DROP CONSTRAINT IF EXISTS pull_request_reviews_state_check;
+        ALTER TABLE pull_request_reviews ADD CONSTRAINT pull_request_reviews_state_check
+            CHECK (state IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED', 'DISMISSED'));
+    END IF;
+END $$;
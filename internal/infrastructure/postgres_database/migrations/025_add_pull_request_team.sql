@@ -0,0 +1,22 @@
+-- Команда PR фиксируется при создании: смена основной команды автора не переносит его PR.
+-- При добавлении колонки существующим PR проставляется текущая основная команда автора
+DO $$ 
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns 
+        WHERE table_name = 'pull_requests' AND column_name = 'team_name'
+    ) THEN
+        ALTER TABLE pull_requests ADD COLUMN team_name VARCHAR(100)
+            REFERENCES teams(name) ON DELETE SET NULL ON UPDATE CASCADE;
+
+        UPDATE pull_requests p SET team_name = (
+            SELECT tm.team_name
+            FROM team_members tm
+            JOIN users u ON u.user_id = tm.user_id
+            WHERE tm.user_id = p.author_id
+            ORDER BY tm.team_name = COALESCE(u.primary_team, '') DESC, tm.joined_at, tm.team_name
+            LIMIT 1);
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_team_status ON pull_requests(team_name, status);
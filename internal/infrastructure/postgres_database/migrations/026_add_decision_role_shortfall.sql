@@ -0,0 +1,3 @@
+-- Невыполненное требование политики к роли: сколько ревьюверов с ролью missing_role не нашлось при назначении
+ALTER TABLE assignment_decisions ADD COLUMN IF NOT EXISTS missing_role VARCHAR(20);
+ALTER TABLE assignment_decisions ADD COLUMN IF NOT EXISTS missing_role_reviewers INT NOT NULL DEFAULT 0;
@@ -22,6 +22,28 @@ func RunMigrations(db *sql.DB) error {
 		"002_create_teams_table.sql",
 		"003_create_team_members_table.sql",
 		"004_create_pull_requests_table.sql",
+		"005_create_team_reviewer_cursors_table.sql",
+		"006_add_review_capacity.sql",
+		"007_create_team_policies_table.sql",
+		"008_create_team_fallbacks_table.sql",
+		"009_create_ownership_rules_table.sql",
+		"010_create_skills_and_labels_tables.sql",
+		"011_create_user_out_of_office_table.sql",
+		"012_add_work_schedule.sql",
+		"013_create_assignment_decisions_table.sql",
+		"014_add_review_sla.sql",
+		"015_create_pull_request_reviews_table.sql",
+		"016_extend_pull_request_lifecycle.sql",
+		"017_add_pinned_reviewers.sql",
+		"018_add_auto_rebalance.sql",
+		"019_add_team_member_roles.sql",
+		"020_add_primary_team.sql",
+		"021_cascade_team_rename.sql",
+		"022_pull_request_list_index.sql",
+		"023_add_pull_request_metadata.sql",
+		"024_add_review_dismissal.sql",
+		"025_add_pull_request_team.sql",
+		"026_add_decision_role_shortfall.sql",
 	}
 
 	for _, filename := range migrationFiles {
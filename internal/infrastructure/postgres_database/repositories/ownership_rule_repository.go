@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"go-project/internal/domain/entities"
+	"go-project/internal/domain/repositories"
+
+	"github.com/lib/pq"
+
+	postgres "go-project/internal/infrastructure/postgres_database"
+)
+
+type OwnershipRuleRepository struct {
+	db *postgres.DB
+}
+
+func NewOwnershipRuleRepository(db *postgres.DB) repositories.OwnershipRuleRepository {
+	return &OwnershipRuleRepository{db: db}
+}
+
+func (r *OwnershipRuleRepository) ReplaceAll(ctx context.Context, rules []*entities.OwnershipRule) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM ownership_rules"); err != nil {
+		return fmt.Errorf("failed to clear ownership rules: %w", err)
+	}
+
+	for _, rule := range rules {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO ownership_rules (position, pattern, owner_teams, owner_users) VALUES ($1, $2, $3, $4)",
+			rule.Position, rule.Pattern, pq.Array(rule.OwnerTeams), pq.Array(rule.OwnerUsers))
+		if err != nil {
+			return fmt.Errorf("failed to add ownership rule: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *OwnershipRuleRepository) GetAll(ctx context.Context) ([]*entities.OwnershipRule, error) {
+	query := `
+        SELECT position, pattern, owner_teams, owner_users
+        FROM ownership_rules
+        ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ownership rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entities.OwnershipRule
+	for rows.Next() {
+		var rule entities.OwnershipRule
+		if err := rows.Scan(&rule.Position, &rule.Pattern,
+			pq.Array(&rule.OwnerTeams), pq.Array(&rule.OwnerUsers)); err != nil {
+			return nil, fmt.Errorf("failed to scan ownership rule: %w", err)
+		}
+		rules = append(rules, &rule)
+	}
+
+	return rules, nil
+}
@@ -3,14 +3,49 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"time"
 
 	"go-project/internal/domain/entities"
 	"go-project/internal/domain/repositories"
 
+	"github.com/lib/pq"
+
 	postgres "go-project/internal/infrastructure/postgres_database"
 )
 
+// Открытый PR, где остался назначенным неактивный ревьювер (замены для него не нашлось)
+const needsReviewerExpr = `(p.status = 'OPEN' AND (EXISTS (
+            SELECT 1 FROM pull_request_reviewers nr JOIN users nu ON nu.user_id = nr.user_id
+            WHERE nr.pull_request_id = p.id AND NOT nu.is_active)
+        OR EXISTS (
+            SELECT 1 FROM team_policies np
+            WHERE np.team_name = p.team_name AND np.min_role_reviewers > (
+                SELECT COUNT(*) FROM pull_request_reviewers nr
+                JOIN team_members nm ON nm.user_id = nr.user_id AND nm.team_name = p.team_name
+                WHERE nr.pull_request_id = p.id
+                    AND array_position(` + roleOrder + `, nm.role)
+                        >= COALESCE(array_position(` + roleOrder + `, np.required_role), 0)))))`
+
+// Роли по старшинству, как в entities.TeamRole
+const roleOrder = `ARRAY['member', 'senior', 'lead']::VARCHAR[]`
+
+// Полный PR одной строкой: ревьюверы и метки собираются подзапросами
+const pullRequestColumns = `p.id, p.name, p.author_id, COALESCE(p.team_name, ''), p.status, p.created_at, p.merged_at, p.updated_at, p.paths,
+            ` + metadataColumns + `,
+            ARRAY(SELECT prr.user_id FROM pull_request_reviewers prr WHERE prr.pull_request_id = p.id),
+            ARRAY(SELECT prr.user_id FROM pull_request_reviewers prr
+                  WHERE prr.pull_request_id = p.id AND prr.is_fallback),
+            ARRAY(SELECT prr.user_id FROM pull_request_reviewers prr
+                  WHERE prr.pull_request_id = p.id AND prr.is_pinned),
+            ARRAY(SELECT l.label FROM pull_request_labels l WHERE l.pull_request_id = p.id ORDER BY l.label),
+            ` + needsReviewerExpr
+
+// Колонки PullRequestMetadata, порядок совпадает с metadataValues и metadataFields
+const metadataColumns = `p.description, p.repository, p.url, p.source_branch, p.target_branch, p.lines_added, p.lines_removed`
+
 type PullRequestRepository struct {
 	db *postgres.DB
 }
@@ -27,23 +62,24 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *entities.PullReq
 	defer tx.Rollback()
 
 	query := `
-        INSERT INTO pull_requests (id, name, author_id, status, created_at, merged_at) 
-        VALUES ($1, $2, $3, $4, $5, $6)`
+        INSERT INTO pull_requests (id, name, author_id, team_name, status, created_at, merged_at, paths,
+            description, repository, url, source_branch, target_branch, lines_added, lines_removed)
+        VALUES ($1, $2, $3, NULLIF($4, ''), $5, COALESCE($6, CURRENT_TIMESTAMP), $7, $8,
+            $9, $10, $11, $12, $13, $14, $15)`
 
-	_, err = tx.ExecContext(ctx, query,
-		pr.ID, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt, pr.MergedAt)
+	args := []any{pr.ID, pr.Name, pr.AuthorID, pr.TeamName, pr.Status, pr.CreatedAt, pr.MergedAt, pq.Array(pr.Paths)}
+	_, err = tx.ExecContext(ctx, query, append(args, metadataValues(&pr.PullRequestMetadata)...)...)
 	if err != nil {
 		return fmt.Errorf("failed to create pull request: %w", err)
 	}
 
-	// Добавляем reviewers
-	for _, reviewerID := range pr.AssignedReviewers {
-		_, err = tx.ExecContext(ctx,
-			"INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES ($1, $2)",
-			pr.ID, reviewerID)
-		if err != nil {
-			return fmt.Errorf("failed to add reviewer: %w", err)
-		}
+	// Добавляем reviewers, время назначения - время создания PR
+	if err := r.saveReviewers(ctx, tx, pr, pr.CreatedAt); err != nil {
+		return err
+	}
+
+	if err := r.saveLabels(ctx, tx, pr); err != nil {
+		return err
 	}
 
 	return tx.Commit()
@@ -51,15 +87,19 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *entities.PullReq
 
 func (r *PullRequestRepository) GetByID(ctx context.Context, id string) (*entities.PullRequest, error) {
 	prQuery := `
-        SELECT id, name, author_id, status, created_at, merged_at 
-        FROM pull_requests WHERE id = $1`
+        SELECT p.id, p.name, p.author_id, COALESCE(p.team_name, ''), p.status, p.created_at, p.merged_at,
+            p.updated_at, p.paths, ` + metadataColumns + `, ` + needsReviewerExpr + `
+        FROM pull_requests p WHERE p.id = $1`
 
 	row := r.db.QueryRowContext(ctx, prQuery, id)
 
 	var pr entities.PullRequest
-	var createdAt, mergedAt sql.NullTime
+	var createdAt, mergedAt, updatedAt sql.NullTime
 
-	err := row.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &createdAt, &mergedAt)
+	dest := []any{&pr.ID, &pr.Name, &pr.AuthorID, &pr.TeamName, &pr.Status, &createdAt, &mergedAt, &updatedAt,
+		pq.Array(&pr.Paths)}
+	dest = append(dest, metadataFields(&pr.PullRequestMetadata)...)
+	err := row.Scan(append(dest, &pr.NeedsReviewer)...)
 	if err == sql.ErrNoRows {
 		return nil, repositories.ErrPullRequestNotFound
 	}
@@ -73,13 +113,19 @@ func (r *PullRequestRepository) GetByID(ctx context.Context, id string) (*entiti
 	if mergedAt.Valid {
 		pr.MergedAt = &mergedAt.Time
 	}
+	if updatedAt.Valid {
+		pr.UpdatedAt = &updatedAt.Time
+	}
 
 	// Получаем reviewers
-	reviewers, err := r.getReviewersForPR(ctx, pr.ID)
-	if err != nil {
+	if err := r.loadReviewers(ctx, &pr); err != nil {
 		return nil, err
 	}
-	pr.AssignedReviewers = reviewers
+
+	labelsQuery := `SELECT ARRAY(SELECT label FROM pull_request_labels WHERE pull_request_id = $1 ORDER BY label)`
+	if err := r.db.QueryRowContext(ctx, labelsQuery, pr.ID).Scan(pq.Array(&pr.Labels)); err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
 
 	return &pr, nil
 }
@@ -135,6 +181,81 @@ func (r *PullRequestRepository) GetByReviewerID(ctx context.Context, reviewerID
 	return prs, nil
 }
 
+func (r *PullRequestRepository) GetByReviewers(ctx context.Context, reviewerIDs []string) ([]*entities.PullRequest, error) {
+	query := `
+        SELECT ` + pullRequestColumns + `
+        FROM pull_requests p
+        WHERE p.id IN (SELECT pull_request_id FROM pull_request_reviewers WHERE user_id = ANY($1))
+        ORDER BY p.id`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(reviewerIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull requests by reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*entities.PullRequest
+	for rows.Next() {
+		pr, err := scanPullRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pull request: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	return prs, rows.Err()
+}
+
+func (r *PullRequestRepository) List(ctx context.Context, filter entities.PullRequestListFilter) ([]*entities.PullRequest, error) {
+	// Направление сортировки и сравнения ключа страницы выбирается из двух констант, а не из ввода
+	order, compare := "ASC", ">"
+	if filter.Descending {
+		order, compare = "DESC", "<"
+	}
+
+	var afterAt *time.Time
+	var afterID string
+	if filter.After != nil {
+		afterAt, afterID = &filter.After.CreatedAt, filter.After.ID
+	}
+
+	query := `
+        SELECT ` + pullRequestColumns + `
+        FROM pull_requests p
+        WHERE ($1 = '' OR p.status::text = $1)
+            AND ($2 = '' OR p.author_id = $2)
+            AND ($3 = '' OR EXISTS (
+                SELECT 1 FROM pull_request_reviewers prr WHERE prr.pull_request_id = p.id AND prr.user_id = $3))
+            AND ($4 = '' OR p.team_name = $4)
+            AND ($5::timestamptz IS NULL OR p.created_at >= $5)
+            AND ($6::timestamptz IS NULL OR p.created_at < $6)
+            AND ($7::timestamptz IS NULL OR p.merged_at >= $7)
+            AND ($8::timestamptz IS NULL OR p.merged_at < $8)
+            AND (NOT $9 OR NOT EXISTS (SELECT 1 FROM pull_request_reviewers prr WHERE prr.pull_request_id = p.id))
+            AND ($10::timestamptz IS NULL OR (p.created_at, p.id) ` + compare + ` ($10, $11))
+        ORDER BY p.created_at ` + order + `, p.id ` + order + `
+        LIMIT $12`
+
+	rows, err := r.db.QueryContext(ctx, query, filter.Status, filter.AuthorID, filter.ReviewerID, filter.TeamName,
+		filter.CreatedFrom, filter.CreatedTo, filter.MergedFrom, filter.MergedTo, filter.NoReviewers,
+		afterAt, afterID, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	prs := []*entities.PullRequest{}
+	for rows.Next() {
+		pr, err := scanPullRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pull request: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	return prs, rows.Err()
+}
+
 func (r *PullRequestRepository) Update(ctx context.Context, pr *entities.PullRequest) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -145,10 +266,13 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *entities.PullReq
 	// Обновляем основную информацию PR
 	query := `
         UPDATE pull_requests 
-        SET name = $1, status = $2, merged_at = $3, updated_at = CURRENT_TIMESTAMP 
-        WHERE id = $4`
+        SET name = $1, status = $2, merged_at = $3, updated_at = COALESCE($4, CURRENT_TIMESTAMP),
+            team_name = NULLIF($6, ''), description = $7, repository = $8, url = $9, source_branch = $10,
+            target_branch = $11, lines_added = $12, lines_removed = $13
+        WHERE id = $5`
 
-	result, err := tx.ExecContext(ctx, query, pr.Name, pr.Status, pr.MergedAt, pr.ID)
+	args := []any{pr.Name, pr.Status, pr.MergedAt, pr.UpdatedAt, pr.ID, pr.TeamName}
+	result, err := tx.ExecContext(ctx, query, append(args, metadataValues(&pr.PullRequestMetadata)...)...)
 	if err != nil {
 		return fmt.Errorf("failed to update pull request: %w", err)
 	}
@@ -161,24 +285,34 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *entities.PullReq
 		return repositories.ErrPullRequestNotFound
 	}
 
-	// Удаляем старых reviewers и добавляем новых
-	_, err = tx.ExecContext(ctx, "DELETE FROM pull_request_reviewers WHERE pull_request_id = $1", pr.ID)
+	// Удаляем снятых reviewers; оставшиеся сохраняют assigned_at, новые получают время изменения
+	_, err = tx.ExecContext(ctx,
+		"DELETE FROM pull_request_reviewers WHERE pull_request_id = $1 AND NOT (user_id = ANY($2))",
+		pr.ID, pq.Array(pr.AssignedReviewers))
 	if err != nil {
 		return fmt.Errorf("failed to delete old reviewers: %w", err)
 	}
 
-	for _, reviewerID := range pr.AssignedReviewers {
-		_, err = tx.ExecContext(ctx,
-			"INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES ($1, $2)",
-			pr.ID, reviewerID)
-		if err != nil {
-			return fmt.Errorf("failed to add reviewer: %w", err)
-		}
+	if err := r.saveReviewers(ctx, tx, pr, pr.UpdatedAt); err != nil {
+		return err
+	}
+
+	if err := r.saveLabels(ctx, tx, pr); err != nil {
+		return err
 	}
 
 	return tx.Commit()
 }
 
+func (r *PullRequestRepository) ResetAssignedAt(ctx context.Context, prID string, at time.Time) error {
+	query := `UPDATE pull_request_reviewers SET assigned_at = $2 WHERE pull_request_id = $1`
+	if _, err := r.db.ExecContext(ctx, query, prID, at); err != nil {
+		return fmt.Errorf("failed to reset reviewers assigned_at: %w", err)
+	}
+
+	return nil
+}
+
 func (r *PullRequestRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM pull_requests WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id)
@@ -197,22 +331,336 @@ func (r *PullRequestRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *PullRequestRepository) getReviewersForPR(ctx context.Context, prID string) ([]string, error) {
-	query := `SELECT user_id FROM pull_request_reviewers WHERE pull_request_id = $1`
+func (r *PullRequestRepository) SaveAssignmentDecision(ctx context.Context, decision *entities.AssignmentDecision) error {
+	candidates, err := json.Marshal(decision.Candidates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal candidates: %w", err)
+	}
+
+	query := `
+        INSERT INTO assignment_decisions (pull_request_id, action, strategy, replaced_user_id, candidates, created_at,
+            missing_role, missing_role_reviewers)
+        VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''), $8)
+        RETURNING id`
+	err = r.db.QueryRowContext(ctx, query,
+		decision.PullRequestID, decision.Action, decision.Strategy, decision.ReplacedUserID, candidates, decision.CreatedAt,
+		decision.MissingRole, decision.MissingRoleReviewers,
+	).Scan(&decision.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save assignment decision: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) GetAssignmentDecisions(ctx context.Context, prID string) ([]*entities.AssignmentDecision, error) {
+	query := `
+        SELECT id, pull_request_id, action, strategy, COALESCE(replaced_user_id, ''), candidates, created_at,
+            COALESCE(missing_role, ''), missing_role_reviewers
+        FROM assignment_decisions
+        WHERE pull_request_id = $1
+        ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment decisions: %w", err)
+	}
+	defer rows.Close()
+
+	decisions := []*entities.AssignmentDecision{}
+	for rows.Next() {
+		var decision entities.AssignmentDecision
+		var candidates []byte
+		if err := rows.Scan(&decision.ID, &decision.PullRequestID, &decision.Action, &decision.Strategy,
+			&decision.ReplacedUserID, &candidates, &decision.CreatedAt,
+			&decision.MissingRole, &decision.MissingRoleReviewers); err != nil {
+			return nil, fmt.Errorf("failed to scan assignment decision: %w", err)
+		}
+		if err := json.Unmarshal(candidates, &decision.Candidates); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal candidates: %w", err)
+		}
+		decisions = append(decisions, &decision)
+	}
+
+	return decisions, rows.Err()
+}
+
+func (r *PullRequestRepository) GetOverdueReviews(ctx context.Context, now time.Time) ([]*entities.OverdueReview, error) {
+	// SLA берется из политики команды PR; уже обработанные назначения
+	// и ревьюверов, отправивших ревью после назначения, пропускаем
+	query := `
+        SELECT prr.pull_request_id, prr.user_id, tp.team_name, prr.assigned_at
+        FROM pull_request_reviewers prr
+        JOIN pull_requests p ON p.id = prr.pull_request_id AND p.status = 'OPEN'
+        JOIN team_policies tp ON tp.team_name = p.team_name AND tp.review_sla_hours > 0
+        WHERE prr.assigned_at + make_interval(hours => tp.review_sla_hours) <= $1
+            AND NOT EXISTS (
+                SELECT 1 FROM review_escalations e
+                WHERE e.pull_request_id = prr.pull_request_id
+                    AND e.reviewer_id = prr.user_id
+                    AND e.assigned_at = prr.assigned_at)
+            AND NOT EXISTS (
+                SELECT 1 FROM pull_request_reviews rv
+                WHERE rv.pull_request_id = prr.pull_request_id
+                    AND rv.reviewer_id = prr.user_id
+                    AND rv.state <> 'DISMISSED'
+                    AND rv.created_at >= prr.assigned_at)
+        ORDER BY prr.assigned_at, prr.pull_request_id, prr.user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []*entities.OverdueReview
+	for rows.Next() {
+		var review entities.OverdueReview
+		if err := rows.Scan(&review.PullRequestID, &review.ReviewerID, &review.TeamName, &review.AssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan overdue review: %w", err)
+		}
+		reviews = append(reviews, &review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (r *PullRequestRepository) SaveEscalation(ctx context.Context, escalation *entities.ReviewEscalation) error {
+	query := `
+        INSERT INTO review_escalations
+            (pull_request_id, reviewer_id, team_name, action, new_reviewer_id, assigned_at, created_at)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)
+        RETURNING id`
+	err := r.db.QueryRowContext(ctx, query,
+		escalation.PullRequestID, escalation.ReviewerID, escalation.TeamName, escalation.Action,
+		escalation.NewReviewerID, escalation.AssignedAt, escalation.CreatedAt,
+	).Scan(&escalation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save review escalation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) GetEscalations(ctx context.Context, teamName string) ([]*entities.ReviewEscalation, error) {
+	query := `
+        SELECT id, pull_request_id, reviewer_id, team_name, action, COALESCE(new_reviewer_id, ''), assigned_at, created_at
+        FROM review_escalations
+        WHERE team_name = $1
+        ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review escalations: %w", err)
+	}
+	defer rows.Close()
+
+	escalations := []*entities.ReviewEscalation{}
+	for rows.Next() {
+		var escalation entities.ReviewEscalation
+		if err := rows.Scan(&escalation.ID, &escalation.PullRequestID, &escalation.ReviewerID, &escalation.TeamName,
+			&escalation.Action, &escalation.NewReviewerID, &escalation.AssignedAt, &escalation.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review escalation: %w", err)
+		}
+		escalations = append(escalations, &escalation)
+	}
+
+	return escalations, rows.Err()
+}
+
+func (r *PullRequestRepository) AddReview(ctx context.Context, review *entities.Review) error {
+	query := `
+        INSERT INTO pull_request_reviews (pull_request_id, reviewer_id, state, comment, dismissed_by, created_at)
+        VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6)
+        RETURNING id`
+	err := r.db.QueryRowContext(ctx, query,
+		review.PullRequestID, review.ReviewerID, review.State, review.Comment, review.DismissedBy, review.CreatedAt,
+	).Scan(&review.ID)
+	if err != nil {
+		return fmt.Errorf("failed to add review: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) GetReviews(ctx context.Context, prID string) ([]*entities.Review, error) {
+	query := `
+        SELECT id, pull_request_id, reviewer_id, state, COALESCE(comment, ''), COALESCE(dismissed_by, ''), created_at
+        FROM pull_request_reviews
+        WHERE pull_request_id = $1
+        ORDER BY id`
+
 	rows, err := r.db.QueryContext(ctx, query, prID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviewers: %w", err)
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*entities.Review{}
+	for rows.Next() {
+		var review entities.Review
+		if err := rows.Scan(&review.ID, &review.PullRequestID, &review.ReviewerID, &review.State,
+			&review.Comment, &review.DismissedBy, &review.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews = append(reviews, &review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (r *PullRequestRepository) GetPendingReviews(ctx context.Context, reviewerIDs []string) ([]*entities.PendingReview, error) {
+	// Начатым считаем ревью, если ревьювер отправил хотя бы одно ревью после назначения
+	query := `
+        SELECT prr.pull_request_id, prr.user_id, prr.assigned_at
+        FROM pull_request_reviewers prr
+        JOIN pull_requests p ON p.id = prr.pull_request_id AND p.status = 'OPEN'
+        WHERE prr.user_id = ANY($1) AND NOT prr.is_pinned
+            AND NOT EXISTS (
+                SELECT 1 FROM pull_request_reviews rv
+                WHERE rv.pull_request_id = prr.pull_request_id
+                    AND rv.reviewer_id = prr.user_id
+                    AND rv.state <> 'DISMISSED'
+                    AND rv.created_at >= prr.assigned_at)
+        ORDER BY prr.assigned_at DESC, prr.pull_request_id, prr.user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(reviewerIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*entities.PendingReview{}
+	for rows.Next() {
+		var review entities.PendingReview
+		if err := rows.Scan(&review.PullRequestID, &review.ReviewerID, &review.AssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pending review: %w", err)
+		}
+		reviews = append(reviews, &review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (r *PullRequestRepository) GetOpenIDsByTeam(ctx context.Context, teamName string) ([]string, error) {
+	query := `
+        SELECT p.id
+        FROM pull_requests p
+        WHERE p.team_name = $1 AND p.status IN ('OPEN', 'DRAFT')
+        ORDER BY p.id`
+
+	rows, err := r.db.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan pull request id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// Добавляет отсутствующих reviewers с временем назначения assignedAt (nil - текущее время БД)
+// и обновляет признак резервного ревьювера у уже назначенных
+func (r *PullRequestRepository) saveReviewers(ctx context.Context, tx *postgres.Tx, pr *entities.PullRequest, assignedAt *time.Time) error {
+	query := `
+        INSERT INTO pull_request_reviewers (pull_request_id, user_id, is_fallback, is_pinned, assigned_at)
+        VALUES ($1, $2, $3, $4, COALESCE($5, CURRENT_TIMESTAMP))
+        ON CONFLICT (pull_request_id, user_id) DO UPDATE
+        SET is_fallback = EXCLUDED.is_fallback, is_pinned = EXCLUDED.is_pinned`
+
+	for _, reviewerID := range pr.AssignedReviewers {
+		_, err := tx.ExecContext(ctx, query, pr.ID, reviewerID,
+			slices.Contains(pr.FallbackReviewers, reviewerID), slices.Contains(pr.PinnedReviewers, reviewerID), assignedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add reviewer: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) loadReviewers(ctx context.Context, pr *entities.PullRequest) error {
+	query := `SELECT user_id, is_fallback, is_pinned FROM pull_request_reviewers WHERE pull_request_id = $1`
+	rows, err := r.db.QueryContext(ctx, query, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get reviewers: %w", err)
 	}
 	defer rows.Close()
 
-	var reviewers []string
 	for rows.Next() {
 		var reviewerID string
-		if err := rows.Scan(&reviewerID); err != nil {
-			return nil, fmt.Errorf("failed to scan reviewer: %w", err)
+		var isFallback, isPinned bool
+		if err := rows.Scan(&reviewerID, &isFallback, &isPinned); err != nil {
+			return fmt.Errorf("failed to scan reviewer: %w", err)
+		}
+		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+		if isFallback {
+			pr.FallbackReviewers = append(pr.FallbackReviewers, reviewerID)
+		}
+		if isPinned {
+			pr.PinnedReviewers = append(pr.PinnedReviewers, reviewerID)
 		}
-		reviewers = append(reviewers, reviewerID)
 	}
 
-	return reviewers, nil
+	return nil
+}
+
+// Заменяет метки PR в рамках транзакции создания/обновления
+func (r *PullRequestRepository) saveLabels(ctx context.Context, tx *postgres.Tx, pr *entities.PullRequest) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM pull_request_labels WHERE pull_request_id = $1", pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to clear labels: %w", err)
+	}
+
+	for _, label := range pr.Labels {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO pull_request_labels (pull_request_id, label) VALUES ($1, $2)",
+			pr.ID, label)
+		if err != nil {
+			return fmt.Errorf("failed to add label: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Читает PR, выбранный через pullRequestColumns
+func scanPullRequest(row interface{ Scan(dest ...any) error }) (*entities.PullRequest, error) {
+	var pr entities.PullRequest
+	var createdAt, mergedAt, updatedAt sql.NullTime
+	dest := []any{&pr.ID, &pr.Name, &pr.AuthorID, &pr.TeamName, &pr.Status, &createdAt, &mergedAt, &updatedAt,
+		pq.Array(&pr.Paths)}
+	dest = append(dest, metadataFields(&pr.PullRequestMetadata)...)
+	if err := row.Scan(append(dest, pq.Array(&pr.AssignedReviewers), pq.Array(&pr.FallbackReviewers),
+		pq.Array(&pr.PinnedReviewers), pq.Array(&pr.Labels), &pr.NeedsReviewer)...); err != nil {
+		return nil, err
+	}
+	if createdAt.Valid {
+		pr.CreatedAt = &createdAt.Time
+	}
+	if mergedAt.Valid {
+		pr.MergedAt = &mergedAt.Time
+	}
+	if updatedAt.Valid {
+		pr.UpdatedAt = &updatedAt.Time
+	}
+	return &pr, nil
+}
+
+// Значения PullRequestMetadata для записи в порядке metadataColumns
+func metadataValues(m *entities.PullRequestMetadata) []any {
+	return []any{m.Description, m.Repository, m.URL, m.SourceBranch, m.TargetBranch, m.LinesAdded, m.LinesRemoved}
+}
+
+// Поля PullRequestMetadata для чтения в порядке metadataColumns
+func metadataFields(m *entities.PullRequestMetadata) []any {
+	return []any{&m.Description, &m.Repository, &m.URL, &m.SourceBranch, &m.TargetBranch, &m.LinesAdded, &m.LinesRemoved}
 }
@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"go-project/internal/domain/entities"
 	"go-project/internal/domain/repositories"
 
+	"github.com/lib/pq"
+
 	postgres "go-project/internal/infrastructure/postgres_database"
 )
 
@@ -28,25 +31,18 @@ func (r *TeamRepository) Create(ctx context.Context, team *entities.Team) error
 	defer tx.Rollback()
 
 	// Создаем команду
-	_, err = tx.ExecContext(ctx, "INSERT INTO teams (name) VALUES ($1)", team.Name)
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO teams (name, default_max_open_reviews) VALUES ($1, $2)",
+		team.Name, team.DefaultMaxOpenReviews)
 	if err != nil {
 		return fmt.Errorf("failed to create team: %w", err)
 	}
 
-	// Добавляем членов команды (автоматически удаляя из старых команд)
+	// Добавляем членов команды, участие в других командах сохраняется
 	for _, user := range team.Members {
-		// Удаляем пользователя из всех предыдущих команд
-		_, err = tx.ExecContext(ctx,
-			"DELETE FROM team_members WHERE user_id = $1",
-			user.UserID)
-		if err != nil {
-			return fmt.Errorf("failed to remove user from previous teams: %w", err)
-		}
-
-		// Добавляем в новую команду
 		_, err = tx.ExecContext(ctx,
-			"INSERT INTO team_members (team_name, user_id) VALUES ($1, $2)",
-			team.Name, user.UserID)
+			"INSERT INTO team_members (team_name, user_id, role) VALUES ($1, $2, $3)",
+			team.Name, user.UserID, memberRole(user.Role))
 		if err != nil {
 			return fmt.Errorf("failed to add team member: %w", err)
 		}
@@ -59,12 +55,19 @@ func (r *TeamRepository) GetByName(ctx context.Context, name string) (*entities.
 	query := `
         SELECT 
             t.name,
+            t.default_max_open_reviews,
             COALESCE(
                 json_agg(
                     json_build_object(
                         'user_id', u.user_id,
                         'username', u.username, 
-                        'is_active', u.is_active
+                        'is_active', u.is_active,
+                        'role', tm.role,
+                        'open_reviews', ` + openReviewsSubquery + `,
+                        'max_open_reviews', u.max_open_reviews,
+                        'review_capacity', COALESCE(u.max_open_reviews, t.default_max_open_reviews),
+                        'skills', ` + userSkillsSubquery + `,
+                        'work_schedule', ` + userScheduleJSON + `
                     )
                 ) FILTER (WHERE u.user_id IS NOT NULL),
                 '[]'
@@ -80,7 +83,7 @@ func (r *TeamRepository) GetByName(ctx context.Context, name string) (*entities.
 	var team entities.Team
 	var membersJSON string
 
-	err := row.Scan(&team.Name, &membersJSON)
+	err := row.Scan(&team.Name, &team.DefaultMaxOpenReviews, &membersJSON)
 	if err == sql.ErrNoRows {
 		return nil, repositories.ErrTeamNotFound
 	}
@@ -97,16 +100,18 @@ func (r *TeamRepository) GetByName(ctx context.Context, name string) (*entities.
 
 func (r *TeamRepository) GetByUserID(ctx context.Context, userID string) (*entities.Team, error) {
 	query := `
-        SELECT t.name 
-        FROM teams t
-        JOIN team_members tm ON t.name = tm.team_name
+        SELECT t.name, t.default_max_open_reviews
+        FROM team_members tm
+        JOIN teams t ON t.name = tm.team_name
+        JOIN users u ON u.user_id = tm.user_id
         WHERE tm.user_id = $1
+        ORDER BY ` + primaryTeamOrder + `
         LIMIT 1`
 
 	row := r.db.QueryRowContext(ctx, query, userID)
 
 	var team entities.Team
-	err := row.Scan(&team.Name)
+	err := row.Scan(&team.Name, &team.DefaultMaxOpenReviews)
 	if err == sql.ErrNoRows {
 		return nil, repositories.ErrTeamNotFound
 	}
@@ -116,9 +121,13 @@ func (r *TeamRepository) GetByUserID(ctx context.Context, userID string) (*entit
 
 	// Получаем всех членов команды
 	membersQuery := `
-        SELECT u.user_id, u.username, u.is_active 
+        SELECT u.user_id, u.username, u.is_active, tm.role, ` + openReviewsSubquery + `,
+            u.max_open_reviews, COALESCE(u.max_open_reviews, t.default_max_open_reviews),
+            ` + userSkillsSubquery + `,
+            u.timezone, u.work_start, u.work_end
         FROM team_members tm
         JOIN users u ON tm.user_id = u.user_id
+        JOIN teams t ON t.name = tm.team_name
         WHERE tm.team_name = $1`
 
 	rows, err := r.db.QueryContext(ctx, membersQuery, team.Name)
@@ -130,9 +139,18 @@ func (r *TeamRepository) GetByUserID(ctx context.Context, userID string) (*entit
 	var members []*entities.User
 	for rows.Next() {
 		var user entities.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive); err != nil {
+		var maxOpenReviews sql.NullInt64
+		var timezone, workStart, workEnd sql.NullString
+		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive, &user.Role, &user.OpenReviews,
+			&maxOpenReviews, &user.ReviewCapacity, pq.Array(&user.Skills),
+			&timezone, &workStart, &workEnd); err != nil {
 			return nil, fmt.Errorf("failed to scan team member: %w", err)
 		}
+		if maxOpenReviews.Valid {
+			limit := int(maxOpenReviews.Int64)
+			user.MaxOpenReviews = &limit
+		}
+		user.Schedule = workSchedule(timezone, workStart, workEnd)
 		members = append(members, &user)
 	}
 
@@ -140,6 +158,34 @@ func (r *TeamRepository) GetByUserID(ctx context.Context, userID string) (*entit
 	return &team, nil
 }
 
+func (r *TeamRepository) GetMemberships(ctx context.Context, userID string) ([]*entities.TeamMembership, error) {
+	query := `
+        SELECT tm.team_name, tm.role, tm.joined_at
+        FROM team_members tm
+        JOIN users u ON u.user_id = tm.user_id
+        WHERE tm.user_id = $1
+        ORDER BY ` + primaryTeamOrder
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user teams: %w", err)
+	}
+	defer rows.Close()
+
+	memberships := []*entities.TeamMembership{}
+	for rows.Next() {
+		var membership entities.TeamMembership
+		if err := rows.Scan(&membership.TeamName, &membership.Role, &membership.JoinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan user team: %w", err)
+		}
+		// Основная команда идет первой
+		membership.IsPrimary = len(memberships) == 0
+		memberships = append(memberships, &membership)
+	}
+
+	return memberships, rows.Err()
+}
+
 func (r *TeamRepository) Update(ctx context.Context, team *entities.Team) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -147,37 +193,40 @@ func (r *TeamRepository) Update(ctx context.Context, team *entities.Team) error
 	}
 	defer tx.Rollback()
 
-	// Проверяем существование команды
-	var exists bool
-	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1)", team.Name).Scan(&exists)
+	result, err := tx.ExecContext(ctx,
+		"UPDATE teams SET default_max_open_reviews = $2 WHERE name = $1",
+		team.Name, team.DefaultMaxOpenReviews)
 	if err != nil {
-		return fmt.Errorf("failed to check team existence: %w", err)
+		return fmt.Errorf("failed to update team: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
-	if !exists {
+	if rows == 0 {
 		return repositories.ErrTeamNotFound
 	}
 
-	// Обновляем состав команды
-	// Сначала удаляем всех текущих членов
-	_, err = tx.ExecContext(ctx, "DELETE FROM team_members WHERE team_name = $1", team.Name)
+	// Обновляем состав команды: убираем выбывших, у оставшихся сохраняется время вступления,
+	// от которого зависит основная команда пользователя
+	userIDs := make([]string, 0, len(team.Members))
+	for _, user := range team.Members {
+		userIDs = append(userIDs, user.UserID)
+	}
+	_, err = tx.ExecContext(ctx,
+		"DELETE FROM team_members WHERE team_name = $1 AND NOT (user_id = ANY($2))",
+		team.Name, pq.Array(userIDs))
 	if err != nil {
-		return fmt.Errorf("failed to clear team members: %w", err)
+		return fmt.Errorf("failed to remove team members: %w", err)
 	}
 
-	// Затем добавляем новых членов (с удалением из старых команд)
+	// Затем добавляем новых членов, участие в других командах сохраняется
 	for _, user := range team.Members {
-		// Удаляем пользователя из всех других команд
-		_, err = tx.ExecContext(ctx,
-			"DELETE FROM team_members WHERE user_id = $1",
-			user.UserID)
-		if err != nil {
-			return fmt.Errorf("failed to remove user from previous teams: %w", err)
-		}
-
-		// Добавляем в текущую команду
-		_, err = tx.ExecContext(ctx,
-			"INSERT INTO team_members (team_name, user_id) VALUES ($1, $2)",
-			team.Name, user.UserID)
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO team_members (team_name, user_id, role) VALUES ($1, $2, $3)
+            ON CONFLICT (team_name, user_id) DO UPDATE SET role = EXCLUDED.role`,
+			team.Name, user.UserID, memberRole(user.Role))
 		if err != nil {
 			return fmt.Errorf("failed to add team member: %w", err)
 		}
@@ -186,9 +235,40 @@ func (r *TeamRepository) Update(ctx context.Context, team *entities.Team) error
 	return tx.Commit()
 }
 
+func (r *TeamRepository) Lock(ctx context.Context, teamName string) error {
+	var name string
+	err := r.db.QueryRowContext(ctx, "SELECT name FROM teams WHERE name = $1 FOR UPDATE", teamName).Scan(&name)
+	if err == sql.ErrNoRows {
+		return repositories.ErrTeamNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock team: %w", err)
+	}
+	return nil
+}
+
 func (r *TeamRepository) Delete(ctx context.Context, teamName string) error {
-	query := `DELETE FROM teams WHERE name = $1`
-	result, err := r.db.ExecContext(ctx, query, teamName)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// PR команды переходят в следующую команду автора (NULL - автор больше ни в одной команде)
+	_, err = tx.ExecContext(ctx, `
+        UPDATE pull_requests p SET team_name = (
+            SELECT tm.team_name
+            FROM team_members tm
+            JOIN users u ON u.user_id = tm.user_id
+            WHERE tm.user_id = p.author_id AND tm.team_name <> $1
+            ORDER BY `+primaryTeamOrder+`
+            LIMIT 1)
+        WHERE p.team_name = $1`, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to move team pull requests: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM teams WHERE name = $1", teamName)
 	if err != nil {
 		return fmt.Errorf("failed to delete team: %w", err)
 	}
@@ -201,35 +281,61 @@ func (r *TeamRepository) Delete(ctx context.Context, teamName string) error {
 		return repositories.ErrTeamNotFound
 	}
 
-	return nil
+	// Правила владения ссылаются на команды по имени без внешнего ключа
+	_, err = tx.ExecContext(ctx,
+		"UPDATE ownership_rules SET owner_teams = array_remove(owner_teams, $1) WHERE $1 = ANY(owner_teams)",
+		teamName)
+	if err != nil {
+		return fmt.Errorf("failed to remove team from ownership rules: %w", err)
+	}
+
+	return tx.Commit()
 }
 
-func (r *TeamRepository) AddMember(ctx context.Context, teamName, userID string, isActive bool) error {
+func (r *TeamRepository) Rename(ctx context.Context, teamName, newName string) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	// Удаляем пользователя из всех предыдущих команд
-	_, err = tx.ExecContext(ctx,
-		"DELETE FROM team_members WHERE user_id = $1",
-		userID)
+	// Участники, политика, курсор, резервные команды и эскалации переносятся через ON UPDATE CASCADE
+	result, err := tx.ExecContext(ctx,
+		"UPDATE teams SET name = $2 WHERE name = $1",
+		teamName, newName)
+	if err != nil {
+		return fmt.Errorf("failed to rename team: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to remove user from previous teams: %w", err)
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return repositories.ErrTeamNotFound
 	}
 
-	// Добавляем в новую команду
 	_, err = tx.ExecContext(ctx,
-		"INSERT INTO team_members (team_name, user_id) VALUES ($1, $2)",
-		teamName, userID)
+		"UPDATE ownership_rules SET owner_teams = array_replace(owner_teams, $1, $2) WHERE $1 = ANY(owner_teams)",
+		teamName, newName)
 	if err != nil {
-		return fmt.Errorf("failed to add team member: %w", err)
+		return fmt.Errorf("failed to rename team in ownership rules: %w", err)
 	}
 
 	return tx.Commit()
 }
 
+func (r *TeamRepository) AddMember(ctx context.Context, teamName, userID string, role entities.TeamRole) error {
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO team_members (team_name, user_id, role) VALUES ($1, $2, $3)",
+		teamName, userID, memberRole(role))
+	if err != nil {
+		return fmt.Errorf("failed to add team member: %w", err)
+	}
+
+	return nil
+}
+
 func (r *TeamRepository) RemoveMember(ctx context.Context, teamName, userID string) error {
 	query := `DELETE FROM team_members WHERE team_name = $1 AND user_id = $2`
 	result, err := r.db.ExecContext(ctx, query, teamName, userID)
@@ -248,23 +354,206 @@ func (r *TeamRepository) RemoveMember(ctx context.Context, teamName, userID stri
 	return nil
 }
 
-// Новый метод для получения всех команд (если нужно)
-func (r *TeamRepository) GetAll(ctx context.Context) ([]*entities.Team, error) {
-	query := `SELECT name FROM teams`
+func (r *TeamRepository) GetReviewerCursor(ctx context.Context, teamName string) (string, error) {
+	query := `SELECT last_user_id FROM team_reviewer_cursors WHERE team_name = $1`
+
+	var userID string
+	err := r.db.QueryRowContext(ctx, query, teamName).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get reviewer cursor: %w", err)
+	}
+
+	return userID, nil
+}
+
+func (r *TeamRepository) SaveReviewerCursor(ctx context.Context, teamName, userID string) error {
+	query := `
+        INSERT INTO team_reviewer_cursors (team_name, last_user_id)
+        VALUES ($1, $2)
+        ON CONFLICT (team_name) DO UPDATE
+        SET last_user_id = EXCLUDED.last_user_id, updated_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.ExecContext(ctx, query, teamName, userID); err != nil {
+		return fmt.Errorf("failed to save reviewer cursor: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) GetPolicy(ctx context.Context, teamName string) (*entities.TeamPolicy, error) {
+	query := `
+        SELECT required_reviewers, min_approvals, COALESCE(strategy, ''), cross_team_fallback, prefer_working_hours,
+            review_sla_hours, sla_action, COALESCE(team_lead_id, ''), COALESCE(required_role, ''),
+            min_role_reviewers, auto_rebalance, large_pr_lines, large_pr_reviewers
+        FROM team_policies
+        WHERE team_name = $1`
+
+	policy := entities.DefaultTeamPolicy(teamName)
+	err := r.db.QueryRowContext(ctx, query, teamName).Scan(
+		&policy.RequiredReviewers, &policy.MinApprovals, &policy.Strategy, &policy.CrossTeamFallback,
+		&policy.PreferWorkingHours, &policy.ReviewSLAHours, &policy.SLAAction, &policy.TeamLeadID,
+		&policy.RequiredRole, &policy.MinRoleReviewers, &policy.AutoRebalance, &policy.LargePRLines,
+		&policy.LargePRReviewers)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get team policy: %w", err)
+	}
+
+	fallbackQuery := `
+        SELECT fallback_team_name
+        FROM team_fallbacks
+        WHERE team_name = $1
+        ORDER BY priority`
+
+	rows, err := r.db.QueryContext(ctx, fallbackQuery, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fallback teams: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fallbackName string
+		if err := rows.Scan(&fallbackName); err != nil {
+			return nil, fmt.Errorf("failed to scan fallback team: %w", err)
+		}
+		policy.FallbackTeams = append(policy.FallbackTeams, fallbackName)
+	}
+
+	return policy, nil
+}
+
+func (r *TeamRepository) SavePolicy(ctx context.Context, policy *entities.TeamPolicy) error {
+	query := `
+        INSERT INTO team_policies (team_name, required_reviewers, min_approvals, strategy, cross_team_fallback,
+            prefer_working_hours, review_sla_hours, sla_action, team_lead_id, required_role, min_role_reviewers,
+            auto_rebalance, large_pr_lines, large_pr_reviewers)
+        VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, NULLIF($9, ''), NULLIF($10, ''), $11, $12, $13, $14)
+        ON CONFLICT (team_name) DO UPDATE
+        SET required_reviewers = EXCLUDED.required_reviewers,
+            min_approvals = EXCLUDED.min_approvals,
+            strategy = EXCLUDED.strategy,
+            cross_team_fallback = EXCLUDED.cross_team_fallback,
+            prefer_working_hours = EXCLUDED.prefer_working_hours,
+            review_sla_hours = EXCLUDED.review_sla_hours,
+            sla_action = EXCLUDED.sla_action,
+            team_lead_id = EXCLUDED.team_lead_id,
+            required_role = EXCLUDED.required_role,
+            min_role_reviewers = EXCLUDED.min_role_reviewers,
+            auto_rebalance = EXCLUDED.auto_rebalance,
+            large_pr_lines = EXCLUDED.large_pr_lines,
+            large_pr_reviewers = EXCLUDED.large_pr_reviewers,
+            updated_at = CURRENT_TIMESTAMP`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query,
+		policy.TeamName, policy.RequiredReviewers, policy.MinApprovals, policy.Strategy, policy.CrossTeamFallback,
+		policy.PreferWorkingHours, policy.ReviewSLAHours, policy.SLAAction, policy.TeamLeadID,
+		policy.RequiredRole, policy.MinRoleReviewers, policy.AutoRebalance, policy.LargePRLines, policy.LargePRReviewers)
+	if err != nil {
+		return fmt.Errorf("failed to save team policy: %w", err)
+	}
+
+	// Список резервных команд заменяем целиком
+	_, err = tx.ExecContext(ctx, "DELETE FROM team_fallbacks WHERE team_name = $1", policy.TeamName)
+	if err != nil {
+		return fmt.Errorf("failed to clear fallback teams: %w", err)
+	}
+
+	for priority, fallbackName := range policy.FallbackTeams {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO team_fallbacks (team_name, fallback_team_name, priority) VALUES ($1, $2, $3)",
+			policy.TeamName, fallbackName, priority)
+		if err != nil {
+			return fmt.Errorf("failed to add fallback team: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *TeamRepository) GetAutoRebalanceTeams(ctx context.Context) ([]string, error) {
+	query := `SELECT team_name FROM team_policies WHERE auto_rebalance ORDER BY team_name`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get teams: %w", err)
+		return nil, fmt.Errorf("failed to get auto rebalance teams: %w", err)
+	}
+	defer rows.Close()
+
+	teamNames := []string{}
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			return nil, fmt.Errorf("failed to scan team name: %w", err)
+		}
+		teamNames = append(teamNames, teamName)
+	}
+
+	return teamNames, rows.Err()
+}
+
+func (r *TeamRepository) List(ctx context.Context, filter entities.TeamListFilter) ([]*entities.TeamSummary, error) {
+	// Загрузку считаем одним проходом по открытым назначениям, а не подзапросом на каждого участника
+	query := `
+        SELECT t.name, t.default_max_open_reviews,
+            COUNT(u.user_id),
+            COUNT(u.user_id) FILTER (WHERE u.is_active),
+            COUNT(u.user_id) FILTER (WHERE load.open_reviews > 0)
+        FROM teams t
+        LEFT JOIN team_members tm ON tm.team_name = t.name
+        LEFT JOIN users u ON u.user_id = tm.user_id
+        LEFT JOIN (
+            SELECT prr.user_id, COUNT(*) AS open_reviews
+            FROM pull_request_reviewers prr
+            JOIN pull_requests p ON p.id = prr.pull_request_id AND p.status = 'OPEN'
+            GROUP BY prr.user_id
+        ) load ON load.user_id = u.user_id
+        WHERE t.name ILIKE $1 AND t.name > $2
+        GROUP BY t.name
+        ORDER BY t.name
+        LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, escapeLike(filter.Prefix)+"%", filter.After, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
 	}
 	defer rows.Close()
 
-	var teams []*entities.Team
+	teams := []*entities.TeamSummary{}
 	for rows.Next() {
-		var team entities.Team
-		if err := rows.Scan(&team.Name); err != nil {
+		var team entities.TeamSummary
+		if err := rows.Scan(&team.TeamName, &team.DefaultMaxOpenReviews, &team.MemberCount,
+			&team.ActiveCount, &team.BusyCount); err != nil {
 			return nil, fmt.Errorf("failed to scan team: %w", err)
 		}
 		teams = append(teams, &team)
 	}
 
-	return teams, nil
+	return teams, rows.Err()
+}
+
+// Экранирует спецсимволы LIKE, чтобы s совпадала буквально
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// Код ошибки Postgres при нарушении ограничения уникальности
+const uniqueViolation = "23505"
+
+// Порядок команд пользователя u (при JOIN с team_members tm): сначала явно выбранная основная,
+// затем в порядке вступления
+const primaryTeamOrder = `tm.team_name = COALESCE(u.primary_team, '') DESC, tm.joined_at, tm.team_name`
+
+// Роль участника при записи в team_members (по умолчанию member)
+func memberRole(role entities.TeamRole) entities.TeamRole {
+	if role == "" {
+		return entities.RoleMember
+	}
+	return role
 }
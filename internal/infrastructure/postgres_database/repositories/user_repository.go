@@ -3,14 +3,50 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"go-project/internal/domain/entities"
 	"go-project/internal/domain/repositories"
 
+	"github.com/lib/pq"
+
 	postgres "go-project/internal/infrastructure/postgres_database"
 )
 
+// Загрузка пользователя u: количество OPEN PR, где он назначен ревьювером
+const openReviewsSubquery = `(
+        SELECT COUNT(*)
+        FROM pull_request_reviewers prr
+        JOIN pull_requests p ON p.id = prr.pull_request_id
+        WHERE prr.user_id = u.user_id AND p.status = 'OPEN'
+    )`
+
+// Навыки пользователя u
+const userSkillsSubquery = `ARRAY(SELECT us.skill FROM user_skills us WHERE us.user_id = u.user_id ORDER BY us.skill)`
+
+// Рабочие часы пользователя u в виде JSON (NULL, если не заданы)
+const userScheduleJSON = `CASE WHEN u.timezone IS NULL THEN NULL
+        ELSE json_build_object('timezone', u.timezone, 'start', u.work_start, 'end', u.work_end) END`
+
+// Поля пользователя u для scanUser. Лимит берется персональный, затем основной команды (primaryTeamJoin),
+// затем по умолчанию ($1)
+const userColumns = `u.user_id, u.username, u.is_active, ` + openReviewsSubquery + `, u.max_open_reviews,
+            COALESCE(u.max_open_reviews, pt.default_max_open_reviews, $1),
+            ` + userSkillsSubquery + `,
+            u.timezone, u.work_start, u.work_end, COALESCE(pt.name, '')`
+
+// Основная команда pt пользователя u
+const primaryTeamJoin = `LEFT JOIN LATERAL (
+            SELECT t.name, t.default_max_open_reviews
+            FROM team_members tm
+            JOIN teams t ON t.name = tm.team_name
+            WHERE tm.user_id = u.user_id
+            ORDER BY ` + primaryTeamOrder + `
+            LIMIT 1
+        ) pt ON true`
+
 type UserRepository struct {
 	db *postgres.DB
 }
@@ -20,20 +56,26 @@ func NewUserRepository(db *postgres.DB) repositories.UserRepository {
 }
 
 func (r *UserRepository) Create(ctx context.Context, user *entities.User) error {
-	query := `INSERT INTO users (user_id, username, is_active) VALUES ($1, $2, $3)`
-	_, err := r.db.ExecContext(ctx, query, user.UserID, user.Username, user.IsActive)
+	query := `INSERT INTO users (user_id, username, is_active, max_open_reviews) VALUES ($1, $2, $3, $4)`
+	_, err := r.db.ExecContext(ctx, query, user.UserID, user.Username, user.IsActive, user.MaxOpenReviews)
 	if err != nil {
+		if conflict := userConflict(err); conflict != nil {
+			return conflict
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 	return nil
 }
 
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*entities.User, error) {
-	query := `SELECT user_id, username, is_active FROM users WHERE user_id = $1`
-	row := r.db.QueryRowContext(ctx, query, id)
+	query := `
+        SELECT ` + userColumns + `
+        FROM users u
+        ` + primaryTeamJoin + `
+        WHERE u.user_id = $2`
+	row := r.db.QueryRowContext(ctx, query, entities.DefaultMaxOpenReviews, id)
 
-	var user entities.User
-	err := row.Scan(&user.UserID, &user.Username, &user.IsActive)
+	user, err := scanUser(row)
 	if err == sql.ErrNoRows {
 		return nil, repositories.ErrUserNotFound
 	}
@@ -41,13 +83,49 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*entities.User
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
 	}
 
-	return &user, nil
+	return user, nil
+}
+
+func (r *UserRepository) List(ctx context.Context, filter entities.UserListFilter) ([]*entities.User, error) {
+	query := `
+        SELECT ` + userColumns + `
+        FROM users u
+        ` + primaryTeamJoin + `
+        WHERE u.user_id > $2
+            AND ($3 = '' OR EXISTS (
+                SELECT 1 FROM team_members tm WHERE tm.user_id = u.user_id AND tm.team_name = $3))
+            AND ($4::boolean IS NULL OR u.is_active = $4)
+            AND ($5::boolean IS NULL OR (` + openReviewsSubquery + ` > 0) = $5)
+            AND u.username ILIKE $6
+        ORDER BY u.user_id
+        LIMIT $7`
+
+	rows, err := r.db.QueryContext(ctx, query, entities.DefaultMaxOpenReviews, filter.After, filter.TeamName,
+		filter.IsActive, filter.Busy, "%"+escapeLike(filter.Search)+"%", filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*entities.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
 }
 
 func (r *UserRepository) Update(ctx context.Context, user *entities.User) error {
-	query := `UPDATE users SET username = $1, is_active = $2, updated_at = CURRENT_TIMESTAMP WHERE user_id = $3`
-	result, err := r.db.ExecContext(ctx, query, user.Username, user.IsActive, user.UserID)
+	query := `UPDATE users SET username = $1, is_active = $2, max_open_reviews = $3, updated_at = CURRENT_TIMESTAMP WHERE user_id = $4`
+	result, err := r.db.ExecContext(ctx, query, user.Username, user.IsActive, user.MaxOpenReviews, user.UserID)
 	if err != nil {
+		if conflict := userConflict(err); conflict != nil {
+			return conflict
+		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
@@ -80,6 +158,185 @@ func (r *UserRepository) SetActive(ctx context.Context, userID string, isActive
 	return nil
 }
 
+func (r *UserRepository) SetPrimaryTeam(ctx context.Context, userID, teamName string) error {
+	query := `UPDATE users SET primary_team = NULLIF($1, ''), updated_at = CURRENT_TIMESTAMP WHERE user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, teamName, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set primary team: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return repositories.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetActiveMany(ctx context.Context, userIDs []string, isActive bool) error {
+	query := `UPDATE users SET is_active = $1, updated_at = CURRENT_TIMESTAMP WHERE user_id = ANY($2)`
+	_, err := r.db.ExecContext(ctx, query, isActive, pq.Array(userIDs))
+	if err != nil {
+		return fmt.Errorf("failed to set users active status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error {
+	query := `UPDATE users SET max_open_reviews = $1, updated_at = CURRENT_TIMESTAMP WHERE user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, maxOpenReviews, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set user max open reviews: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return repositories.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetSkills(ctx context.Context, userID string, skills []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM user_skills WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("failed to clear user skills: %w", err)
+	}
+
+	for _, skill := range skills {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO user_skills (user_id, skill) VALUES ($1, $2)",
+			userID, skill)
+		if err != nil {
+			return fmt.Errorf("failed to add user skill: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *UserRepository) SetWorkSchedule(ctx context.Context, userID string, schedule *entities.WorkSchedule) error {
+	var timezone, workStart, workEnd sql.NullString
+	if schedule != nil {
+		timezone = sql.NullString{String: schedule.Timezone, Valid: true}
+		workStart = sql.NullString{String: schedule.Start, Valid: true}
+		workEnd = sql.NullString{String: schedule.End, Valid: true}
+	}
+
+	query := `
+        UPDATE users
+        SET timezone = $1, work_start = $2, work_end = $3, updated_at = CURRENT_TIMESTAMP
+        WHERE user_id = $4`
+	result, err := r.db.ExecContext(ctx, query, timezone, workStart, workEnd, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set user work schedule: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return repositories.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) AddOutOfOffice(ctx context.Context, period *entities.OutOfOfficePeriod) error {
+	query := `
+        INSERT INTO user_out_of_office (user_id, starts_at, ends_at, reason)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id
+    `
+	err := r.db.QueryRowContext(ctx, query, period.UserID, period.StartsAt, period.EndsAt, period.Reason).
+		Scan(&period.ID)
+	if err != nil {
+		return fmt.Errorf("failed to add out of office period: %w", err)
+	}
+	return nil
+}
+
+func (r *UserRepository) GetOutOfOffice(ctx context.Context, userID string) ([]*entities.OutOfOfficePeriod, error) {
+	query := `
+        SELECT id, user_id, starts_at, ends_at, reason
+        FROM user_out_of_office
+        WHERE user_id = $1
+        ORDER BY starts_at, id
+    `
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get out of office periods: %w", err)
+	}
+	defer rows.Close()
+
+	periods := []*entities.OutOfOfficePeriod{}
+	for rows.Next() {
+		var period entities.OutOfOfficePeriod
+		if err := rows.Scan(&period.ID, &period.UserID, &period.StartsAt, &period.EndsAt, &period.Reason); err != nil {
+			return nil, fmt.Errorf("failed to scan out of office period: %w", err)
+		}
+		periods = append(periods, &period)
+	}
+
+	return periods, rows.Err()
+}
+
+func (r *UserRepository) DeleteOutOfOffice(ctx context.Context, userID string, periodID int64) error {
+	query := `DELETE FROM user_out_of_office WHERE id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, periodID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete out of office period: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return repositories.ErrOutOfOfficeNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetOutOfOfficeUserIDs(ctx context.Context, at time.Time) ([]string, error) {
+	query := `
+        SELECT DISTINCT user_id
+        FROM user_out_of_office
+        WHERE starts_at <= $1 AND ends_at > $1
+    `
+	rows, err := r.db.QueryContext(ctx, query, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get out of office users: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan out of office user: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
 func (r *UserRepository) Delete(ctx context.Context, userID string) error {
 	query := `DELETE FROM users WHERE user_id = $1`
 	result, err := r.db.ExecContext(ctx, query, userID)
@@ -97,3 +354,47 @@ func (r *UserRepository) Delete(ctx context.Context, userID string) error {
 
 	return nil
 }
+
+// Рабочие часы из колонок users (nil, если часовой пояс не задан)
+func workSchedule(timezone, workStart, workEnd sql.NullString) *entities.WorkSchedule {
+	if !timezone.Valid {
+		return nil
+	}
+	return &entities.WorkSchedule{
+		Timezone: timezone.String,
+		Start:    workStart.String,
+		End:      workEnd.String,
+	}
+}
+
+// Читает пользователя, выбранного через userColumns
+func scanUser(row interface{ Scan(dest ...any) error }) (*entities.User, error) {
+	var user entities.User
+	var maxOpenReviews sql.NullInt64
+	var timezone, workStart, workEnd sql.NullString
+	err := row.Scan(&user.UserID, &user.Username, &user.IsActive, &user.OpenReviews, &maxOpenReviews, &user.ReviewCapacity,
+		pq.Array(&user.Skills), &timezone, &workStart, &workEnd, &user.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxOpenReviews.Valid {
+		limit := int(maxOpenReviews.Int64)
+		user.MaxOpenReviews = &limit
+	}
+	user.Schedule = workSchedule(timezone, workStart, workEnd)
+
+	return &user, nil
+}
+
+// Нарушение уникальности users: занят user_id или username (nil - другая ошибка)
+func userConflict(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != uniqueViolation {
+		return nil
+	}
+	if pqErr.Constraint == "users_username_key" {
+		return repositories.ErrUsernameTaken
+	}
+	return repositories.ErrUserAlreadyExists
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type txKey struct{}
+
+// Tx - транзакция репозитория. Если в контексте уже есть транзакция из WithinTransaction,
+// Tx работает внутри нее через SAVEPOINT, а окончательную фиксацию выполняет внешняя транзакция
+type Tx struct {
+	*sql.Tx
+	savepoint string
+	done      bool
+}
+
+// WithinTransaction выполняет fn в одной транзакции: все вызовы репозиториев с переданным
+// в fn контекстом идут через нее. Если fn вернула ошибку, изменения откатываются.
+// Вложенный вызов выполняет fn во внешней транзакции
+func (db *DB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// BeginTx начинает транзакцию или точку сохранения во внешней транзакции из контекста
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	outer, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if !ok {
+		tx, err := db.DB.BeginTx(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &Tx{Tx: tx}, nil
+	}
+
+	savepoint := "repository_tx"
+	if _, err := outer.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: outer, savepoint: savepoint}, nil
+}
+
+func (tx *Tx) Commit() error {
+	if tx.savepoint == "" {
+		return tx.Tx.Commit()
+	}
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+	_, err := tx.Tx.Exec("RELEASE SAVEPOINT " + tx.savepoint)
+	return err
+}
+
+func (tx *Tx) Rollback() error {
+	if tx.savepoint == "" {
+		return tx.Tx.Rollback()
+	}
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+	_, err := tx.Tx.Exec("ROLLBACK TO SAVEPOINT " + tx.savepoint)
+	return err
+}
+
+// Запросы вне BeginTx тоже выполняются во внешней транзакции, если она есть в контексте
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return db.DB.ExecContext(ctx, query, args...)
+}
+
+func (db *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx.QueryContext(ctx, query, args...)
+	}
+	return db.DB.QueryContext(ctx, query, args...)
+}
+
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return db.DB.QueryRowContext(ctx, query, args...)
+}
@@ -29,11 +29,12 @@ func HandleDomainError(w http.ResponseWriter, err error) {
 
 func GetHTTPStatus(code errors.ErrorCode) int {
 	switch code {
-	case errors.ErrTeamExists, errors.ErrUserInAnotherTeam:
+	case errors.ErrTeamExists, errors.ErrInvalidRequest:
 		return http.StatusBadRequest
 	case errors.ErrNotFound:
 		return http.StatusNotFound
-	case errors.ErrPRExists, errors.ErrPRMerged, errors.ErrNotAssigned, errors.ErrNoCandidate:
+	case errors.ErrPRExists, errors.ErrPRMerged, errors.ErrNotAssigned, errors.ErrNoCandidate, errors.ErrMergeBlocked,
+		errors.ErrInvalidTransition, errors.ErrTeamHasOpenPRs, errors.ErrUserExists, errors.ErrUsernameTaken:
 		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
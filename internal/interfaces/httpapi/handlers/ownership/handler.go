@@ -0,0 +1,44 @@
+package ownership
+
+import (
+	"encoding/json"
+	"go-project/internal/application/usecases"
+	"go-project/internal/interfaces/httpapi/common"
+	"net/http"
+)
+
+type OwnershipHandler struct {
+	ownershipUseCase *usecases.OwnershipUseCase
+}
+
+func NewOwnershipHandler(ownershipUseCase *usecases.OwnershipUseCase) *OwnershipHandler {
+	return &OwnershipHandler{
+		ownershipUseCase: ownershipUseCase,
+	}
+}
+
+func (h *OwnershipHandler) PostOwnershipImport(w http.ResponseWriter, r *http.Request) {
+	var req ImportCodeownersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	rules, err := h.ownershipUseCase.ImportCodeowners(r.Context(), req.Content)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toRulesResponse(rules))
+}
+
+func (h *OwnershipHandler) GetOwnershipRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.ownershipUseCase.GetRules(r.Context())
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toRulesResponse(rules))
+}
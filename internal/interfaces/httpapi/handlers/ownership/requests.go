@@ -0,0 +1,6 @@
+package ownership
+
+// ImportCodeownersRequest запрос на импорт файла CODEOWNERS (заменяет все правила)
+type ImportCodeownersRequest struct {
+	Content string `json:"content" example:"*.sql @org/backend\n/deploy/ @u11"`
+}
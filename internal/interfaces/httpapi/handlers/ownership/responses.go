@@ -0,0 +1,15 @@
+package ownership
+
+import "go-project/internal/domain/entities"
+
+// OwnershipRulesResponse ответ со списком правил владения
+type OwnershipRulesResponse struct {
+	Rules []*entities.OwnershipRule `json:"rules"`
+}
+
+func (h *OwnershipHandler) toRulesResponse(rules []*entities.OwnershipRule) OwnershipRulesResponse {
+	if rules == nil {
+		rules = []*entities.OwnershipRule{}
+	}
+	return OwnershipRulesResponse{Rules: rules}
+}
@@ -3,8 +3,11 @@ package pullrequests
 import (
 	"encoding/json"
 	"go-project/internal/application/usecases"
+	"go-project/internal/domain/entities"
 	"go-project/internal/interfaces/httpapi/common"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type PullRequestHandler struct {
@@ -24,7 +27,23 @@ func (h *PullRequestHandler) PostPullRequestCreate(w http.ResponseWriter, r *htt
 		return
 	}
 
-	pr, err := h.prUseCase.CreatePR(r.Context(), req.AuthorId, req.PullRequestId, req.PullRequestName)
+	opts := usecases.CreatePROptions{
+		Strategy: entities.ReviewerStrategy(req.ReviewerStrategy),
+		Paths:    req.Paths,
+		Labels:   req.Labels,
+		Draft:    req.Draft,
+		Metadata: entities.PullRequestMetadata{
+			Description:  req.Description,
+			Repository:   req.Repository,
+			URL:          req.URL,
+			SourceBranch: req.SourceBranch,
+			TargetBranch: req.TargetBranch,
+			LinesAdded:   req.LinesAdded,
+			LinesRemoved: req.LinesRemoved,
+		},
+	}
+
+	pr, err := h.prUseCase.CreatePR(r.Context(), req.AuthorId, req.PullRequestId, req.PullRequestName, opts)
 	if err != nil {
 		common.HandleDomainError(w, err)
 		return
@@ -33,6 +52,54 @@ func (h *PullRequestHandler) PostPullRequestCreate(w http.ResponseWriter, r *htt
 	common.WriteJSON(w, http.StatusCreated, h.toPullRequestResponse(pr))
 }
 
+func (h *PullRequestHandler) PostPullRequestPreviewAssignment(w http.ResponseWriter, r *http.Request) {
+	var req PreviewAssignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	opts := usecases.CreatePROptions{
+		Strategy: entities.ReviewerStrategy(req.ReviewerStrategy),
+		Paths:    req.Paths,
+		Labels:   req.Labels,
+		Metadata: entities.PullRequestMetadata{LinesAdded: req.LinesAdded, LinesRemoved: req.LinesRemoved},
+	}
+
+	plan, err := h.prUseCase.PreviewAssignment(r.Context(), req.AuthorId, opts)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toPreviewAssignmentResponse(req.AuthorId, plan))
+}
+
+func (h *PullRequestHandler) PostPullRequestUpdate(w http.ResponseWriter, r *http.Request) {
+	var req UpdatePRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := h.prUseCase.UpdatePR(r.Context(), req.PullRequestId, usecases.PullRequestUpdate{
+		Name:         req.PullRequestName,
+		Description:  req.Description,
+		Repository:   req.Repository,
+		URL:          req.URL,
+		SourceBranch: req.SourceBranch,
+		TargetBranch: req.TargetBranch,
+		LinesAdded:   req.LinesAdded,
+		LinesRemoved: req.LinesRemoved,
+	})
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toPullRequestResponse(pr))
+}
+
 func (h *PullRequestHandler) PostPullRequestMerge(w http.ResponseWriter, r *http.Request) {
 	var req MergePRRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -50,6 +117,54 @@ func (h *PullRequestHandler) PostPullRequestMerge(w http.ResponseWriter, r *http
 	common.WriteJSON(w, http.StatusOK, response)
 }
 
+func (h *PullRequestHandler) PostPullRequestMarkReady(w http.ResponseWriter, r *http.Request) {
+	var req MarkReadyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := h.prUseCase.MarkReady(r.Context(), req.PullRequestId, entities.ReviewerStrategy(req.ReviewerStrategy))
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toPullRequestResponse(pr))
+}
+
+func (h *PullRequestHandler) PostPullRequestClose(w http.ResponseWriter, r *http.Request) {
+	var req ClosePRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := h.prUseCase.ClosePR(r.Context(), req.PullRequestId)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toPullRequestResponse(pr))
+}
+
+func (h *PullRequestHandler) PostPullRequestReopen(w http.ResponseWriter, r *http.Request) {
+	var req ReopenPRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := h.prUseCase.ReopenPR(r.Context(), req.PullRequestId)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toPullRequestResponse(pr))
+}
+
 func (h *PullRequestHandler) PostPullRequestReassign(w http.ResponseWriter, r *http.Request) {
 	var req ReassignPRRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -57,7 +172,8 @@ func (h *PullRequestHandler) PostPullRequestReassign(w http.ResponseWriter, r *h
 		return
 	}
 
-	pr, newReviewer, err := h.prUseCase.ReassignReviewer(r.Context(), req.PullRequestId, req.OldUserId)
+	strategy := entities.ReviewerStrategy(req.ReviewerStrategy)
+	pr, newReviewer, err := h.prUseCase.ReassignReviewer(r.Context(), req.PullRequestId, req.OldUserId, strategy)
 	if err != nil {
 		common.HandleDomainError(w, err)
 		return
@@ -71,6 +187,127 @@ func (h *PullRequestHandler) PostPullRequestReassign(w http.ResponseWriter, r *h
 	common.WriteJSON(w, http.StatusOK, response)
 }
 
+func (h *PullRequestHandler) PostPullRequestAddReviewer(w http.ResponseWriter, r *http.Request) {
+	var req AddReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := h.prUseCase.AddReviewer(r.Context(), req.PullRequestId, req.UserId, req.Pin)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toPullRequestResponse(pr))
+}
+
+func (h *PullRequestHandler) PostPullRequestRemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	var req RemoveReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := h.prUseCase.RemoveReviewer(r.Context(), req.PullRequestId, req.UserId)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toPullRequestResponse(pr))
+}
+
+func (h *PullRequestHandler) PostPullRequestPinReviewer(w http.ResponseWriter, r *http.Request) {
+	var req PinReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	pr, err := h.prUseCase.PinReviewer(r.Context(), req.PullRequestId, req.UserId, req.Pinned)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toPullRequestResponse(pr))
+}
+
+func (h *PullRequestHandler) GetPullRequest(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	pr, err := h.prUseCase.GetPR(r.Context(), prID)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toPullRequestResponse(pr))
+}
+
+func (h *PullRequestHandler) GetPullRequestList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := entities.PullRequestListFilter{
+		Status:     entities.PullRequestStatus(query.Get("status")),
+		AuthorID:   query.Get("author_id"),
+		ReviewerID: query.Get("reviewer_id"),
+		TeamName:   query.Get("team_name"),
+	}
+
+	switch query.Get("order") {
+	case "", "desc":
+		filter.Descending = true
+	case "asc":
+	default:
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "order must be asc or desc")
+		return
+	}
+
+	times := []struct {
+		name   string
+		target **time.Time
+	}{
+		{"created_from", &filter.CreatedFrom},
+		{"created_to", &filter.CreatedTo},
+		{"merged_from", &filter.MergedFrom},
+		{"merged_to", &filter.MergedTo},
+	}
+	var err error
+	for _, param := range times {
+		if *param.target, err = optionalTime(query.Get(param.name)); err != nil {
+			common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", param.name+" must be an RFC 3339 timestamp")
+			return
+		}
+	}
+
+	if value := query.Get("no_reviewers"); value != "" {
+		if filter.NoReviewers, err = strconv.ParseBool(value); err != nil {
+			common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "no_reviewers must be a boolean")
+			return
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "limit must be an integer")
+			return
+		}
+	}
+
+	prs, nextCursor, err := h.prUseCase.ListPRs(r.Context(), filter, query.Get("cursor"))
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toPullRequestListResponse(prs, nextCursor))
+}
+
 // Возвращает статистику по PR для пользователя
 func (h *PullRequestHandler) GetUserPRStats(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("userId")
@@ -91,14 +328,105 @@ func (h *PullRequestHandler) GetUserPRStats(w http.ResponseWriter, r *http.Reque
 		TotalAuthored:          stats.TotalAuthored,
 		TotalAssignedForReview: stats.TotalAssignedForReview,
 		AuthoredStats: PRStatusStatsResponse{
+			Draft:  stats.AuthoredStats.Draft,
 			Open:   stats.AuthoredStats.Open,
 			Merged: stats.AuthoredStats.Merged,
+			Closed: stats.AuthoredStats.Closed,
 		},
 		ReviewerStats: PRStatusStatsResponse{
+			Draft:  stats.ReviewerStats.Draft,
 			Open:   stats.ReviewerStats.Open,
 			Merged: stats.ReviewerStats.Merged,
+			Closed: stats.ReviewerStats.Closed,
 		},
 	}
 
 	common.WriteJSON(w, http.StatusOK, response)
 }
+
+func (h *PullRequestHandler) GetAssignmentLog(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	decisions, err := h.prUseCase.GetAssignmentLog(r.Context(), prID)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, AssignmentLogResponse{
+		PullRequestID: prID,
+		Decisions:     decisions,
+	})
+}
+
+func (h *PullRequestHandler) PostPullRequestReview(w http.ResponseWriter, r *http.Request) {
+	var req SubmitReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	review, err := h.prUseCase.SubmitReview(r.Context(), req.PullRequestId, req.ReviewerId,
+		entities.ReviewState(req.State), req.Comment)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusCreated, ReviewResponse{Review: review})
+}
+
+func (h *PullRequestHandler) PostPullRequestDismissReview(w http.ResponseWriter, r *http.Request) {
+	var req DismissReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	review, err := h.prUseCase.DismissReview(r.Context(), req.PullRequestId, req.ReviewerId,
+		req.DismissedBy, req.Reason)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusCreated, ReviewResponse{Review: review})
+}
+
+func (h *PullRequestHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	reviews, status, err := h.prUseCase.GetReviews(r.Context(), prID)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, ReviewsResponse{
+		PullRequestID:      prID,
+		Reviews:            reviews,
+		ApprovedBy:         status.ApprovedBy,
+		ChangesRequestedBy: status.ChangesRequestedBy,
+	})
+}
+
+// Необязательная метка времени из параметра запроса ("" - nil)
+func optionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
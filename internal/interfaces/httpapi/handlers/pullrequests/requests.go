@@ -5,6 +5,46 @@ type CreatePRRequest struct {
 	AuthorId        string `json:"author_id" example:"u1"`
 	PullRequestId   string `json:"pull_request_id" example:"pr-1001"`
 	PullRequestName string `json:"pull_request_name" example:"Add search"`
+	// Необязательно: random, round_robin, least_open_reviews
+	ReviewerStrategy string `json:"reviewer_strategy,omitempty" example:"round_robin"`
+	// Необязательно: измененные файлы, их владельцы назначаются в первую очередь
+	Paths []string `json:"paths,omitempty" example:"internal/db/migrations/005.sql"`
+	// Необязательно: метки PR, ревьюверы с подходящими навыками назначаются в первую очередь
+	Labels []string `json:"labels,omitempty" example:"backend,postgres"`
+	// Необязательно: создать черновик без ревьюверов (назначаются в markReady)
+	Draft bool `json:"draft,omitempty" example:"false"`
+	// Необязательно: сведения о PR; по размеру политика команды может назначить больше ревьюверов
+	Description  string `json:"description,omitempty" example:"Adds full-text search"`
+	Repository   string `json:"repository,omitempty" example:"acme/search"`
+	URL          string `json:"url,omitempty" example:"https://git.example.com/acme/search/pull/1001"`
+	SourceBranch string `json:"source_branch,omitempty" example:"feature/search"`
+	TargetBranch string `json:"target_branch,omitempty" example:"main"`
+	LinesAdded   int    `json:"lines_added,omitempty" example:"120"`
+	LinesRemoved int    `json:"lines_removed,omitempty" example:"30"`
+}
+
+// PreviewAssignmentRequest запрос на предварительный подбор ревьюверов без создания PR
+type PreviewAssignmentRequest struct {
+	AuthorId string `json:"author_id" example:"u1"`
+	// Необязательно: random, round_robin, least_open_reviews
+	ReviewerStrategy string   `json:"reviewer_strategy,omitempty" example:"round_robin"`
+	Paths            []string `json:"paths,omitempty" example:"internal/db/migrations/005.sql"`
+	Labels           []string `json:"labels,omitempty" example:"backend,postgres"`
+	LinesAdded       int      `json:"lines_added,omitempty" example:"120"`
+	LinesRemoved     int      `json:"lines_removed,omitempty" example:"30"`
+}
+
+// UpdatePRRequest запрос на изменение названия и сведений о PR; отсутствующие поля не меняются
+type UpdatePRRequest struct {
+	PullRequestId   string  `json:"pull_request_id" example:"pr-1001"`
+	PullRequestName *string `json:"pull_request_name,omitempty" example:"Add search"`
+	Description     *string `json:"description,omitempty" example:"Adds full-text search"`
+	Repository      *string `json:"repository,omitempty" example:"acme/search"`
+	URL             *string `json:"url,omitempty" example:"https://git.example.com/acme/search/pull/1001"`
+	SourceBranch    *string `json:"source_branch,omitempty" example:"feature/search"`
+	TargetBranch    *string `json:"target_branch,omitempty" example:"main"`
+	LinesAdded      *int    `json:"lines_added,omitempty" example:"120"`
+	LinesRemoved    *int    `json:"lines_removed,omitempty" example:"30"`
 }
 
 // MergePRRequest запрос на мерж PR
@@ -12,8 +52,65 @@ type MergePRRequest struct {
 	PullRequestId string `json:"pull_request_id" example:"pr-1001"`
 }
 
+// MarkReadyRequest запрос на перевод черновика в OPEN
+type MarkReadyRequest struct {
+	PullRequestId string `json:"pull_request_id" example:"pr-1001"`
+	// Необязательно: random, round_robin, least_open_reviews
+	ReviewerStrategy string `json:"reviewer_strategy,omitempty" example:"round_robin"`
+}
+
+// ClosePRRequest запрос на закрытие PR без мержа
+type ClosePRRequest struct {
+	PullRequestId string `json:"pull_request_id" example:"pr-1001"`
+}
+
+// ReopenPRRequest запрос на повторное открытие закрытого PR
+type ReopenPRRequest struct {
+	PullRequestId string `json:"pull_request_id" example:"pr-1001"`
+}
+
+// AddReviewerRequest запрос на ручное добавление ревьювера
+type AddReviewerRequest struct {
+	PullRequestId string `json:"pull_request_id" example:"pr-1001"`
+	UserId        string `json:"user_id" example:"u4"`
+	// Необязательно: сразу закрепить ревьювера
+	Pin bool `json:"pin,omitempty" example:"true"`
+}
+
+// RemoveReviewerRequest запрос на снятие ревьювера без замены
+type RemoveReviewerRequest struct {
+	PullRequestId string `json:"pull_request_id" example:"pr-1001"`
+	UserId        string `json:"user_id" example:"u2"`
+}
+
+// PinReviewerRequest запрос на закрепление или открепление ревьювера
+type PinReviewerRequest struct {
+	PullRequestId string `json:"pull_request_id" example:"pr-1001"`
+	UserId        string `json:"user_id" example:"u2"`
+	Pinned        bool   `json:"pinned" example:"true"`
+}
+
+// SubmitReviewRequest запрос на отправку ревью назначенным ревьювером
+type SubmitReviewRequest struct {
+	PullRequestId string `json:"pull_request_id" example:"pr-1001"`
+	ReviewerId    string `json:"reviewer_id" example:"u2"`
+	// APPROVED, CHANGES_REQUESTED или COMMENTED
+	State   string `json:"state" example:"APPROVED"`
+	Comment string `json:"comment,omitempty" example:"LGTM"`
+}
+
+// DismissReviewRequest запрос на отмену запроса изменений ревьювера
+type DismissReviewRequest struct {
+	PullRequestId string `json:"pull_request_id" example:"pr-1001"`
+	ReviewerId    string `json:"reviewer_id" example:"u3"`
+	DismissedBy   string `json:"dismissed_by" example:"u1"`
+	Reason        string `json:"reason" example:"Reviewer left the project"`
+}
+
 // ReassignPRRequest запрос на переназначение ревьювера
 type ReassignPRRequest struct {
 	OldUserId     string `json:"old_reviewer_id" example:"u2"`
 	PullRequestId string `json:"pull_request_id" example:"pr-1001"`
+	// Необязательно: random, round_robin, least_open_reviews
+	ReviewerStrategy string `json:"reviewer_strategy,omitempty" example:"least_open_reviews"`
 }
@@ -1,19 +1,35 @@
 package pullrequests
 
 import (
+	"go-project/internal/application/usecases"
 	"go-project/internal/domain/entities"
 	"time"
 )
 
+// Информация о пулл-реквесте
+type PullRequestInfoResponse struct {
+	PullRequestID     string   `json:"pull_request_id" example:"pr-1001"`
+	PullRequestName   string   `json:"pull_request_name" example:"Add search"`
+	AuthorID          string   `json:"author_id" example:"u1"`
+	TeamName          string   `json:"team_name,omitempty" example:"backend"` // основная команда автора при создании PR
+	Status            string   `json:"status" example:"OPEN"`                 // DRAFT, OPEN, MERGED или CLOSED
+	AssignedReviewers []string `json:"assigned_reviewers" example:"u2,u3"`
+	FallbackReviewers []string `json:"fallback_reviewers,omitempty" example:"u7"` // назначены из резервных команд
+	PinnedReviewers   []string `json:"pinned_reviewers,omitempty" example:"u2"`   // закреплены вручную
+	Labels            []string `json:"labels,omitempty" example:"backend,postgres"`
+	Paths             []string `json:"paths,omitempty" example:"internal/db/migrations/005.sql"`
+	// Назначен неактивный ревьювер, замены для которого не нашлось
+	NeedsReviewer bool       `json:"needs_reviewer" example:"false"`
+	CreatedAt     *time.Time `json:"createdAt"`
+	MergedAt      *time.Time `json:"mergedAt"` // нет, пока PR не слит
+	UpdatedAt     *time.Time `json:"updatedAt"`
+	// Описание, репозиторий, ссылка, ветки и размер PR
+	entities.PullRequestMetadata
+}
+
 // Ответ в виде пулл-реквеста
 type PullRequestResponse struct {
-	PR struct {
-		PullRequestID     string   `json:"pull_request_id" example:"pr-1001"`
-		PullRequestName   string   `json:"pull_request_name" example:"Add search"`
-		AuthorID          string   `json:"author_id" example:"u1"`
-		Status            string   `json:"status" example:"OPEN"`
-		AssignedReviewers []string `json:"assigned_reviewers" example:"u2,u3"`
-	} `json:"pr"`
+	PR PullRequestInfoResponse `json:"pr"`
 }
 
 // Пулл-реквест, который слили
@@ -22,15 +38,23 @@ type PullRequestMergedResponse struct {
 		PullRequestID     string     `json:"pull_request_id" example:"pr-1001"`
 		PullRequestName   string     `json:"pull_request_name" example:"Add search"`
 		AuthorID          string     `json:"author_id" example:"u1"`
+		TeamName          string     `json:"team_name,omitempty" example:"backend"`
 		Status            string     `json:"status" example:"OPEN"`
 		AssignedReviewers []string   `json:"assigned_reviewers" example:"u2,u3"`
+		FallbackReviewers []string   `json:"fallback_reviewers,omitempty" example:"u7"`
+		PinnedReviewers   []string   `json:"pinned_reviewers,omitempty" example:"u2"`
+		Labels            []string   `json:"labels,omitempty" example:"backend,postgres"`
+		Paths             []string   `json:"paths,omitempty" example:"internal/db/migrations/005.sql"`
+		NeedsReviewer     bool       `json:"needs_reviewer" example:"false"`
 		MergedAt          *time.Time `json:"mergedAt"`
+		entities.PullRequestMetadata
 	} `json:"pr"`
 }
 
-// Ответ со списком PR
+// Страница списка PR
 type PullRequestListResponse struct {
-	PullRequests []entities.PullRequestShort `json:"pull_requests"`
+	PullRequests []PullRequestInfoResponse `json:"pull_requests"`
+	NextCursor   string                    `json:"next_cursor,omitempty" example:"eyJpZCI6InByLTEwMDEifQ"`
 }
 
 // Ответ переназначения ревьювера
@@ -39,6 +63,41 @@ type ReassignReviewerResponse struct {
 	ReplacedBy string              `json:"replaced_by" example:"u5"`
 }
 
+// Ответ предварительного подбора ревьюверов
+type PreviewAssignmentResponse struct {
+	AuthorID          string `json:"author_id" example:"u1"`
+	TeamName          string `json:"team_name" example:"backend"`
+	Strategy          string `json:"strategy" example:"least_open_reviews"`
+	RequiredReviewers int    `json:"required_reviewers" example:"2"`
+	// Сколько ревьюверов с ролью missing_role, требуемой политикой, не нашлось
+	MissingRole          string                       `json:"missing_role,omitempty" example:"senior"`
+	MissingRoleReviewers int                          `json:"missing_role_reviewers,omitempty" example:"1"`
+	Labels               []string                     `json:"labels,omitempty" example:"postgres"`
+	AssignedReviewers    []string                     `json:"assigned_reviewers" example:"u2,u3"`
+	FallbackReviewers    []string                     `json:"fallback_reviewers,omitempty" example:"u7"`
+	Candidates           []entities.CandidateDecision `json:"candidates"`
+}
+
+// Ответ с журналом решений о назначении ревьюверов
+type AssignmentLogResponse struct {
+	PullRequestID string                         `json:"pull_request_id" example:"pr-1001"`
+	Decisions     []*entities.AssignmentDecision `json:"decisions"`
+}
+
+// Ответ на отправку ревью
+type ReviewResponse struct {
+	Review *entities.Review `json:"review"`
+}
+
+// Ответ с историей ревью PR
+type ReviewsResponse struct {
+	PullRequestID string             `json:"pull_request_id" example:"pr-1001"`
+	Reviews       []*entities.Review `json:"reviews"`
+	// Действующие решения: одобрения назначенных ревьюверов и неотмененные запросы изменений, в том числе снятых ревьюверов
+	ApprovedBy         []string `json:"approved_by" example:"u2"`
+	ChangesRequestedBy []string `json:"changes_requested_by" example:"u3"`
+}
+
 // UserPRStatsResponse - ответ со статистикой по PR пользователя
 type UserPRStatsResponse struct {
 	UserID                 string                `json:"user_id"`
@@ -51,19 +110,43 @@ type UserPRStatsResponse struct {
 
 // PRStatusStatsResponse - статистика по статусам PR
 type PRStatusStatsResponse struct {
+	Draft  int `json:"draft"`
 	Open   int `json:"open"`
 	Merged int `json:"merged"`
+	Closed int `json:"closed"`
 }
 
 // Методы преобразования
 func (h *PullRequestHandler) toPullRequestResponse(pr *entities.PullRequest) PullRequestResponse {
-	var response PullRequestResponse
-	response.PR.PullRequestID = pr.ID
-	response.PR.PullRequestName = pr.Name
-	response.PR.AuthorID = pr.AuthorID
-	response.PR.Status = string(pr.Status)
-	response.PR.AssignedReviewers = pr.AssignedReviewers
-	return response
+	return PullRequestResponse{PR: h.toPullRequestInfoResponse(pr)}
+}
+
+func (h *PullRequestHandler) toPullRequestInfoResponse(pr *entities.PullRequest) PullRequestInfoResponse {
+	return PullRequestInfoResponse{
+		PullRequestID:       pr.ID,
+		PullRequestName:     pr.Name,
+		AuthorID:            pr.AuthorID,
+		TeamName:            pr.TeamName,
+		Status:              string(pr.Status),
+		AssignedReviewers:   pr.AssignedReviewers,
+		FallbackReviewers:   pr.FallbackReviewers,
+		PinnedReviewers:     pr.PinnedReviewers,
+		Labels:              pr.Labels,
+		Paths:               pr.Paths,
+		NeedsReviewer:       pr.NeedsReviewer,
+		CreatedAt:           pr.CreatedAt,
+		MergedAt:            pr.MergedAt,
+		UpdatedAt:           pr.UpdatedAt,
+		PullRequestMetadata: pr.PullRequestMetadata,
+	}
+}
+
+func (h *PullRequestHandler) toPullRequestListResponse(prs []*entities.PullRequest, nextCursor string) PullRequestListResponse {
+	items := make([]PullRequestInfoResponse, len(prs))
+	for i, pr := range prs {
+		items[i] = h.toPullRequestInfoResponse(pr)
+	}
+	return PullRequestListResponse{PullRequests: items, NextCursor: nextCursor}
 }
 
 func (h *PullRequestHandler) toPullRequestMergedResponse(pr *entities.PullRequest) PullRequestMergedResponse {
@@ -71,8 +154,34 @@ func (h *PullRequestHandler) toPullRequestMergedResponse(pr *entities.PullReques
 	response.PR.PullRequestID = pr.ID
 	response.PR.PullRequestName = pr.Name
 	response.PR.AuthorID = pr.AuthorID
+	response.PR.TeamName = pr.TeamName
 	response.PR.Status = string(pr.Status)
 	response.PR.AssignedReviewers = pr.AssignedReviewers
+	response.PR.FallbackReviewers = pr.FallbackReviewers
+	response.PR.PinnedReviewers = pr.PinnedReviewers
+	response.PR.Labels = pr.Labels
+	response.PR.Paths = pr.Paths
+	response.PR.NeedsReviewer = pr.NeedsReviewer
 	response.PR.MergedAt = pr.MergedAt
+	response.PR.PullRequestMetadata = pr.PullRequestMetadata
 	return response
 }
+
+func (h *PullRequestHandler) toPreviewAssignmentResponse(authorID string, plan *usecases.AssignmentPlan) PreviewAssignmentResponse {
+	reviewers := plan.Reviewers
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+	return PreviewAssignmentResponse{
+		AuthorID:             authorID,
+		TeamName:             plan.TeamName,
+		Strategy:             string(plan.Strategy),
+		RequiredReviewers:    plan.RequiredReviewers,
+		MissingRole:          string(plan.MissingRole),
+		MissingRoleReviewers: plan.MissingRoleReviewers,
+		Labels:               plan.Labels,
+		AssignedReviewers:    reviewers,
+		FallbackReviewers:    plan.FallbackReviewers,
+		Candidates:           plan.Candidates,
+	}
+}
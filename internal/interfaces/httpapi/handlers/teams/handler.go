@@ -6,15 +6,18 @@ import (
 	"go-project/internal/domain/entities"
 	"go-project/internal/interfaces/httpapi/common"
 	"net/http"
+	"strconv"
 )
 
 type TeamHandler struct {
 	teamUseCase *usecases.TeamUseCase
+	slaUseCase  *usecases.ReviewSLAUseCase
 }
 
-func NewTeamHandler(teamUseCase *usecases.TeamUseCase) *TeamHandler {
+func NewTeamHandler(teamUseCase *usecases.TeamUseCase, slaUseCase *usecases.ReviewSLAUseCase) *TeamHandler {
 	return &TeamHandler{
 		teamUseCase: teamUseCase,
+		slaUseCase:  slaUseCase,
 	}
 }
 
@@ -48,3 +51,224 @@ func (h *TeamHandler) GetTeamGet(w http.ResponseWriter, r *http.Request) {
 
 	common.WriteJSON(w, http.StatusOK, h.toTeamResponse(team))
 }
+
+func (h *TeamHandler) GetTeamList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit := 0
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "limit must be an integer")
+			return
+		}
+	}
+
+	teams, nextCursor, err := h.teamUseCase.ListTeams(r.Context(), query.Get("prefix"), query.Get("cursor"), limit)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, TeamListResponse{Teams: teams, NextCursor: nextCursor})
+}
+
+func (h *TeamHandler) PostTeamUpdate(w http.ResponseWriter, r *http.Request) {
+	var team entities.Team
+	if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if team.Name == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	updated, summary, err := h.teamUseCase.UpdateTeam(r.Context(), &team)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toTeamMembersChangeResponse(updated, summary))
+}
+
+func (h *TeamHandler) PostTeamDelete(w http.ResponseWriter, r *http.Request) {
+	var req DeleteTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if req.TeamName == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	openPRs, err := h.teamUseCase.DeleteTeam(r.Context(), req.TeamName, req.Force)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, TeamDeleteResponse{TeamName: req.TeamName, OpenPullRequests: openPRs})
+}
+
+func (h *TeamHandler) PostTeamRename(w http.ResponseWriter, r *http.Request) {
+	var req RenameTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if req.TeamName == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	team, err := h.teamUseCase.RenameTeam(r.Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toTeamResponse(team))
+}
+
+func (h *TeamHandler) PostTeamAddMember(w http.ResponseWriter, r *http.Request) {
+	var req AddMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if req.TeamName == "" || req.UserId == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name and user_id are required")
+		return
+	}
+
+	team, err := h.teamUseCase.AddMember(r.Context(), req.TeamName, req.UserId, req.Role)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toTeamResponse(team))
+}
+
+func (h *TeamHandler) PostTeamRemoveMember(w http.ResponseWriter, r *http.Request) {
+	var req RemoveMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if req.TeamName == "" || req.UserId == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name and user_id are required")
+		return
+	}
+
+	team, summary, err := h.teamUseCase.RemoveMember(r.Context(), req.TeamName, req.UserId)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toTeamMembersChangeResponse(team, summary))
+}
+
+func (h *TeamHandler) GetTeamPolicy(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	policy, err := h.teamUseCase.GetPolicy(r.Context(), teamName)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, TeamPolicyResponse{Policy: *policy})
+}
+
+func (h *TeamHandler) PutTeamPolicy(w http.ResponseWriter, r *http.Request) {
+	var policy entities.TeamPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if policy.TeamName == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	updated, err := h.teamUseCase.UpdatePolicy(r.Context(), &policy)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, TeamPolicyResponse{Policy: *updated})
+}
+
+func (h *TeamHandler) PostTeamDeactivateMembers(w http.ResponseWriter, r *http.Request) {
+	var req DeactivateMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if req.TeamName == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	summary, err := h.teamUseCase.DeactivateMembers(r.Context(), req.TeamName, req.UserIds)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toDeactivateMembersResponse(req.TeamName, req.UserIds, summary))
+}
+
+func (h *TeamHandler) PostTeamRebalance(w http.ResponseWriter, r *http.Request) {
+	var req RebalanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if req.TeamName == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	moves, err := h.teamUseCase.RebalanceTeam(r.Context(), req.TeamName)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, TeamRebalanceResponse{TeamName: req.TeamName, Moves: moves})
+}
+
+func (h *TeamHandler) GetTeamEscalations(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		return
+	}
+
+	escalations, err := h.slaUseCase.GetEscalations(r.Context(), teamName)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, TeamEscalationsResponse{TeamName: teamName, Escalations: escalations})
+}
@@ -0,0 +1,39 @@
+package teams
+
+import "go-project/internal/domain/entities"
+
+// DeactivateMembersRequest запрос на массовую деактивацию участников команды
+type DeactivateMembersRequest struct {
+	TeamName string   `json:"team_name" example:"backend"`
+	UserIds  []string `json:"user_ids" example:"u2,u3"`
+}
+
+// RebalanceRequest запрос на перераспределение ревью между участниками команды
+type RebalanceRequest struct {
+	TeamName string `json:"team_name" example:"backend"`
+}
+
+// DeleteTeamRequest запрос на удаление команды
+type DeleteTeamRequest struct {
+	TeamName string `json:"team_name" example:"backend"`
+	Force    bool   `json:"force" example:"false"` // удалить, даже если у авторов команды есть открытые PR
+}
+
+// RenameTeamRequest запрос на переименование команды
+type RenameTeamRequest struct {
+	TeamName    string `json:"team_name" example:"backend"`
+	NewTeamName string `json:"new_team_name" example:"core"`
+}
+
+// AddMemberRequest запрос на добавление существующего пользователя в команду
+type AddMemberRequest struct {
+	TeamName string            `json:"team_name" example:"backend"`
+	UserId   string            `json:"user_id" example:"u11"`
+	Role     entities.TeamRole `json:"role,omitempty" example:"senior"` // по умолчанию member
+}
+
+// RemoveMemberRequest запрос на исключение пользователя из команды
+type RemoveMemberRequest struct {
+	TeamName string `json:"team_name" example:"backend"`
+	UserId   string `json:"user_id" example:"u2"`
+}
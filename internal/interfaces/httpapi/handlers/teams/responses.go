@@ -1,12 +1,109 @@
 package teams
 
-import "go-project/internal/domain/entities"
+import (
+	"go-project/internal/application/usecases"
+	"go-project/internal/domain/entities"
+)
 
 // TeamResponse ответ с информацией о команде
 type TeamResponse struct {
 	Team entities.Team `json:"team"`
 }
 
+// TeamListResponse страница списка команд
+type TeamListResponse struct {
+	Teams []*entities.TeamSummary `json:"teams"`
+	// Курсор следующей страницы, нет на последней
+	NextCursor string `json:"next_cursor,omitempty" example:"ImJhY2tlbmQi"`
+}
+
+// TeamPolicyResponse ответ с политикой ревью команды
+type TeamPolicyResponse struct {
+	Policy entities.TeamPolicy `json:"policy"`
+}
+
+// Замена ревьювера на PR
+type ReviewerReplacementResponse struct {
+	PullRequestID string `json:"pull_request_id" example:"pr-1001"`
+	OldReviewerID string `json:"old_reviewer_id" example:"u2"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty" example:"u5"`
+}
+
+// DeactivateMembersResponse итог массовой деактивации
+type DeactivateMembersResponse struct {
+	TeamName    string                        `json:"team_name" example:"backend"`
+	Deactivated []string                      `json:"deactivated" example:"u2,u3"`
+	Reassigned  []ReviewerReplacementResponse `json:"reassigned"`
+	// Замены не нашлось, ревьювер остается назначенным
+	Unassignable []ReviewerReplacementResponse `json:"unassignable"`
+	// PR не в статусе OPEN, где пользователи были ревьюверами
+	Untouched []string `json:"untouched" example:"pr-900"`
+}
+
+// TeamMembersChangeResponse команда после изменения состава и замены выбывших в ее открытых PR
+type TeamMembersChangeResponse struct {
+	Team       entities.Team                 `json:"team"`
+	Reassigned []ReviewerReplacementResponse `json:"reassigned"`
+	// Замены не нашлось, выбывший остается назначенным
+	Unassignable []ReviewerReplacementResponse `json:"unassignable"`
+}
+
+// TeamDeleteResponse итог удаления команды
+type TeamDeleteResponse struct {
+	TeamName string `json:"team_name" example:"backend"`
+	// Открытые PR и черновики авторов команды (при удалении с force)
+	OpenPullRequests []string `json:"open_pull_requests" example:"pr-1001"`
+}
+
+// TeamEscalationsResponse история обработки просроченных ревью команды
+type TeamEscalationsResponse struct {
+	TeamName    string                       `json:"team_name" example:"backend"`
+	Escalations []*entities.ReviewEscalation `json:"escalations"`
+}
+
+// TeamRebalanceResponse переносы ревью, сделанные при перераспределении нагрузки
+type TeamRebalanceResponse struct {
+	TeamName string                 `json:"team_name" example:"backend"`
+	Moves    []*entities.ReviewMove `json:"moves"`
+}
+
 func (h *TeamHandler) toTeamResponse(team *entities.Team) TeamResponse {
 	return TeamResponse{Team: *team}
 }
+
+func (h *TeamHandler) toDeactivateMembersResponse(
+	teamName string,
+	userIDs []string,
+	summary *usecases.ReassignmentSummary,
+) DeactivateMembersResponse {
+	return DeactivateMembersResponse{
+		TeamName:     teamName,
+		Deactivated:  userIDs,
+		Reassigned:   toReplacementResponses(summary.Reassigned),
+		Unassignable: toReplacementResponses(summary.Unassignable),
+		Untouched:    summary.Untouched,
+	}
+}
+
+func (h *TeamHandler) toTeamMembersChangeResponse(
+	team *entities.Team,
+	summary *usecases.ReassignmentSummary,
+) TeamMembersChangeResponse {
+	return TeamMembersChangeResponse{
+		Team:         *team,
+		Reassigned:   toReplacementResponses(summary.Reassigned),
+		Unassignable: toReplacementResponses(summary.Unassignable),
+	}
+}
+
+func toReplacementResponses(replacements []usecases.ReviewerReplacement) []ReviewerReplacementResponse {
+	response := make([]ReviewerReplacementResponse, len(replacements))
+	for i, replacement := range replacements {
+		response[i] = ReviewerReplacementResponse{
+			PullRequestID: replacement.PullRequestID,
+			OldReviewerID: replacement.OldReviewerID,
+			NewReviewerID: replacement.NewReviewerID,
+		}
+	}
+	return response
+}
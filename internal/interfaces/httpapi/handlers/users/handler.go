@@ -3,8 +3,10 @@ package users
 import (
 	"encoding/json"
 	"go-project/internal/application/usecases"
+	"go-project/internal/domain/entities"
 	"go-project/internal/interfaces/httpapi/common"
 	"net/http"
+	"strconv"
 )
 
 type UserHandler struct {
@@ -19,6 +21,92 @@ func NewUserHandler(userUseCase *usecases.UserUseCase, prUseCase *usecases.PullR
 	}
 }
 
+func (h *UserHandler) PostUsersAdd(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	user := &entities.User{
+		UserID:         req.UserId,
+		Username:       req.Username,
+		IsActive:       req.IsActive == nil || *req.IsActive,
+		MaxOpenReviews: req.MaxOpenReviews,
+	}
+	created, err := h.userUseCase.CreateUser(r.Context(), user)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusCreated, h.toUserResponse(created, created.TeamName))
+}
+
+func (h *UserHandler) GetUsersGet(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	user, err := h.userUseCase.GetUser(r.Context(), userID)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toUserResponse(user, user.TeamName))
+}
+
+func (h *UserHandler) GetUsersList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := entities.UserListFilter{
+		TeamName: query.Get("team_name"),
+		Search:   query.Get("search"),
+	}
+
+	var err error
+	if filter.IsActive, err = optionalBool(query.Get("is_active")); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "is_active must be a boolean")
+		return
+	}
+	if filter.Busy, err = optionalBool(query.Get("busy")); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "busy must be a boolean")
+		return
+	}
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "limit must be an integer")
+			return
+		}
+	}
+
+	users, nextCursor, err := h.userUseCase.ListUsers(r.Context(), filter, query.Get("cursor"))
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toUserListResponse(users, nextCursor))
+}
+
+func (h *UserHandler) PostUsersUpdate(w http.ResponseWriter, r *http.Request) {
+	var req UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	user, err := h.userUseCase.UpdateUser(r.Context(), req.UserId, req.Username)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toUserResponse(user, user.TeamName))
+}
+
 func (h *UserHandler) GetUsersGetReview(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
@@ -26,13 +114,19 @@ func (h *UserHandler) GetUsersGetReview(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	user, err := h.userUseCase.GetUser(r.Context(), userID)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
 	prs, err := h.prUseCase.GetPRsForReview(r.Context(), userID)
 	if err != nil {
 		common.HandleDomainError(w, err)
 		return
 	}
 
-	response := h.toUserPullRequestsResponse(userID, prs)
+	response := h.toUserPullRequestsResponse(user, prs)
 	common.WriteJSON(w, http.StatusOK, response)
 }
 
@@ -43,12 +137,176 @@ func (h *UserHandler) PostUsersSetIsActive(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	user, teamName, err := h.userUseCase.SetUserActive(r.Context(), req.UserId, req.IsActive)
+	user, teamName, summary, err := h.userUseCase.SetUserActive(r.Context(), req.UserId, req.IsActive)
 	if err != nil {
 		common.HandleDomainError(w, err)
 		return
 	}
 
-	response := h.toUserResponse(user, teamName)
+	response := h.toSetUserActiveResponse(user, teamName, summary)
 	common.WriteJSON(w, http.StatusOK, response)
 }
+
+func (h *UserHandler) PostUsersSetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var req SetMaxOpenReviewsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	user, err := h.userUseCase.SetMaxOpenReviews(r.Context(), req.UserId, req.MaxOpenReviews)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	response := h.toUserResponse(user, "")
+	common.WriteJSON(w, http.StatusOK, response)
+}
+
+func (h *UserHandler) PostUsersSetWorkSchedule(w http.ResponseWriter, r *http.Request) {
+	var req SetWorkScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	user, err := h.userUseCase.SetWorkSchedule(r.Context(), req.UserId, req.Schedule)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	response := h.toUserResponse(user, "")
+	common.WriteJSON(w, http.StatusOK, response)
+}
+
+func (h *UserHandler) GetUsersSkills(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	user, err := h.userUseCase.GetUser(r.Context(), userID)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toUserSkillsResponse(user))
+}
+
+func (h *UserHandler) PostUsersSetSkills(w http.ResponseWriter, r *http.Request) {
+	var req SetSkillsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	user, err := h.userUseCase.SetSkills(r.Context(), req.UserId, req.Skills)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toUserSkillsResponse(user))
+}
+
+func (h *UserHandler) GetUsersTeams(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	memberships, err := h.userUseCase.GetTeams(r.Context(), userID)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toUserTeamsResponse(userID, memberships))
+}
+
+func (h *UserHandler) PostUsersSetPrimaryTeam(w http.ResponseWriter, r *http.Request) {
+	var req SetPrimaryTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	memberships, err := h.userUseCase.SetPrimaryTeam(r.Context(), req.UserId, req.TeamName)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toUserTeamsResponse(req.UserId, memberships))
+}
+
+func (h *UserHandler) GetUsersOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	periods, err := h.userUseCase.GetOutOfOffice(r.Context(), userID)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, h.toOutOfOfficeListResponse(userID, periods))
+}
+
+func (h *UserHandler) PostUsersOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	var req AddOutOfOfficeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	period, err := h.userUseCase.AddOutOfOffice(r.Context(), req.UserId, req.StartsAt, req.EndsAt, req.Reason)
+	if err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusCreated, h.toOutOfOfficeResponse(period))
+}
+
+func (h *UserHandler) DeleteUsersOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		return
+	}
+
+	periodID, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		common.WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "id must be an integer")
+		return
+	}
+
+	if err := h.userUseCase.DeleteOutOfOffice(r.Context(), userID, periodID); err != nil {
+		common.HandleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Необязательный логический параметр запроса ("" - не задан)
+func optionalBool(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -1,7 +1,58 @@
 package users
 
+import (
+	"go-project/internal/domain/entities"
+	"time"
+)
+
+// CreateUserRequest запрос на создание пользователя вне команды
+type CreateUserRequest struct {
+	UserId         string `json:"user_id" example:"u20"`
+	Username       string `json:"username" example:"Alice"`
+	IsActive       *bool  `json:"is_active,omitempty" example:"true"` // по умолчанию true
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty" example:"2"`
+}
+
+// UpdateUserRequest запрос на изменение имени пользователя
+type UpdateUserRequest struct {
+	UserId   string `json:"user_id" example:"u1"`
+	Username string `json:"username" example:"alice"`
+}
+
 // SetUserActiveRequest запрос на установку активности пользователя
 type SetUserActiveRequest struct {
 	IsActive bool   `json:"is_active" example:"true"`
 	UserId   string `json:"user_id" example:"u1"`
 }
+
+// SetMaxOpenReviewsRequest запрос на установку персонального лимита открытых ревью
+type SetMaxOpenReviewsRequest struct {
+	UserId         string `json:"user_id" example:"u1"`
+	MaxOpenReviews *int   `json:"max_open_reviews" example:"2"` // null - использовать лимит команды
+}
+
+// SetSkillsRequest запрос на замену навыков пользователя
+type SetSkillsRequest struct {
+	UserId string   `json:"user_id" example:"u1"`
+	Skills []string `json:"skills" example:"backend,postgres"`
+}
+
+// SetWorkScheduleRequest запрос на установку рабочих часов пользователя
+type SetWorkScheduleRequest struct {
+	UserId   string                 `json:"user_id" example:"u1"`
+	Schedule *entities.WorkSchedule `json:"work_schedule"` // null - без ограничений
+}
+
+// SetPrimaryTeamRequest запрос на выбор основной команды пользователя
+type SetPrimaryTeamRequest struct {
+	UserId   string `json:"user_id" example:"u1"`
+	TeamName string `json:"team_name" example:"platform"` // "" - основной станет первая команда пользователя
+}
+
+// AddOutOfOfficeRequest запрос на планирование периода отсутствия
+type AddOutOfOfficeRequest struct {
+	UserId   string    `json:"user_id" example:"u1"`
+	StartsAt time.Time `json:"starts_at" example:"2025-07-01T00:00:00Z"`
+	EndsAt   time.Time `json:"ends_at" example:"2025-07-15T00:00:00Z"`
+	Reason   string    `json:"reason,omitempty" example:"vacation"`
+}
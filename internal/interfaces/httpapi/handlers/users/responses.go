@@ -1,15 +1,44 @@
 package users
 
-import "go-project/internal/domain/entities"
+import (
+	"go-project/internal/application/usecases"
+	"go-project/internal/domain/entities"
+	"time"
+)
+
+// Информация о пользователе
+type UserInfoResponse struct {
+	UserID   string `json:"user_id" example:"u2"`
+	Username string `json:"username" example:"Bob"`
+	TeamName string `json:"team_name,omitempty" example:"backend"` // опционально, если есть связь с командой
+	IsActive bool   `json:"is_active" example:"false"`
+	// Количество открытых ревью (не влияет на is_active)
+	OpenReviews int `json:"open_reviews" example:"1"`
+	// Действующий лимит открытых ревью
+	MaxOpenReviews int `json:"max_open_reviews" example:"3"`
+	// Рабочие часы, если заданы
+	WorkSchedule *entities.WorkSchedule `json:"work_schedule,omitempty"`
+}
 
 // Ответ в виде информации о пользователе
 type UserResponse struct {
-	User struct {
-		UserID   string `json:"user_id" example:"u2"`
-		Username string `json:"username" example:"Bob"`
-		TeamName string `json:"team_name,omitempty" example:"backend"` // опционально, если есть связь с командой
-		IsActive bool   `json:"is_active" example:"false"`
-	} `json:"user"`
+	User UserInfoResponse `json:"user"`
+}
+
+// Страница списка пользователей
+type UserListResponse struct {
+	Users []UserInfoResponse `json:"users"`
+	// Курсор следующей страницы, нет на последней
+	NextCursor string `json:"next_cursor,omitempty" example:"InUyIg"`
+}
+
+// Ответ на смену активности пользователя
+type SetUserActiveResponse struct {
+	UserResponse
+	// OPEN PR, где деактивированный пользователь заменен другим ревьювером
+	ReassignedPullRequests []string `json:"reassigned_pull_requests" example:"pr-1001"`
+	// OPEN PR, где замены не нашлось: пользователь остается назначенным, PR помечен needs_reviewer
+	NeedsReviewerPullRequests []string `json:"needs_reviewer_pull_requests" example:"pr-1002"`
 }
 
 // Информация о PR для ревью
@@ -22,23 +51,108 @@ type PullRequestReviewResponse struct {
 
 // Ответ со списком PR для ревью пользователя
 type UserPullRequestsResponse struct {
-	UserID       string                      `json:"user_id" example:"u2"`
-	PullRequests []PullRequestReviewResponse `json:"pull_requests"`
+	UserID         string                      `json:"user_id" example:"u2"`
+	OpenReviews    int                         `json:"open_reviews" example:"1"`
+	MaxOpenReviews int                         `json:"max_open_reviews" example:"3"`
+	PullRequests   []PullRequestReviewResponse `json:"pull_requests"`
+}
+
+// Ответ со списком навыков пользователя
+type UserSkillsResponse struct {
+	UserID string   `json:"user_id" example:"u1"`
+	Skills []string `json:"skills" example:"backend,postgres"`
+}
+
+// Участие пользователя в команде
+type UserTeamResponse struct {
+	TeamName string `json:"team_name" example:"backend"`
+	Role     string `json:"role" example:"senior"`
+	// От имени основной команды пользователь создает PR
+	IsPrimary bool      `json:"is_primary" example:"true"`
+	JoinedAt  time.Time `json:"joined_at" example:"2025-07-01T00:00:00Z"`
+}
+
+// Ответ со списком команд пользователя
+type UserTeamsResponse struct {
+	UserID      string             `json:"user_id" example:"u1"`
+	PrimaryTeam string             `json:"primary_team,omitempty" example:"backend"` // пусто, если пользователь не в команде
+	Teams       []UserTeamResponse `json:"teams"`
+}
+
+// Период отсутствия пользователя
+type OutOfOfficeResponse struct {
+	ID       int64     `json:"id" example:"1"`
+	UserID   string    `json:"user_id" example:"u1"`
+	StartsAt time.Time `json:"starts_at" example:"2025-07-01T00:00:00Z"`
+	EndsAt   time.Time `json:"ends_at" example:"2025-07-15T00:00:00Z"`
+	Reason   string    `json:"reason,omitempty" example:"vacation"`
+}
+
+// Ответ со списком периодов отсутствия пользователя
+type OutOfOfficeListResponse struct {
+	UserID  string                `json:"user_id" example:"u1"`
+	Periods []OutOfOfficeResponse `json:"periods"`
 }
 
 func (h *UserHandler) toUserResponse(user *entities.User, teamName string) UserResponse {
-	var response UserResponse
-	response.User.UserID = user.UserID
-	response.User.Username = user.Username
-	response.User.IsActive = user.IsActive
-	response.User.TeamName = teamName
+	return UserResponse{User: h.toUserInfoResponse(user, teamName)}
+}
+
+func (h *UserHandler) toUserInfoResponse(user *entities.User, teamName string) UserInfoResponse {
+	return UserInfoResponse{
+		UserID:         user.UserID,
+		Username:       user.Username,
+		TeamName:       teamName,
+		IsActive:       user.IsActive,
+		OpenReviews:    user.OpenReviews,
+		MaxOpenReviews: user.ReviewCapacity,
+		WorkSchedule:   user.Schedule,
+	}
+}
+
+func (h *UserHandler) toUserListResponse(users []*entities.User, nextCursor string) UserListResponse {
+	response := UserListResponse{
+		Users:      make([]UserInfoResponse, len(users)),
+		NextCursor: nextCursor,
+	}
+
+	for i, user := range users {
+		response.Users[i] = h.toUserInfoResponse(user, user.TeamName)
+	}
+
 	return response
 }
 
-func (h *UserHandler) toUserPullRequestsResponse(userID string, prs []entities.PullRequestShort) UserPullRequestsResponse {
+func (h *UserHandler) toSetUserActiveResponse(
+	user *entities.User,
+	teamName string,
+	summary *usecases.ReassignmentSummary,
+) SetUserActiveResponse {
+	response := SetUserActiveResponse{
+		UserResponse:              h.toUserResponse(user, teamName),
+		ReassignedPullRequests:    []string{},
+		NeedsReviewerPullRequests: []string{},
+	}
+	if summary == nil {
+		return response
+	}
+
+	for _, replacement := range summary.Reassigned {
+		response.ReassignedPullRequests = append(response.ReassignedPullRequests, replacement.PullRequestID)
+	}
+	for _, replacement := range summary.Unassignable {
+		response.NeedsReviewerPullRequests = append(response.NeedsReviewerPullRequests, replacement.PullRequestID)
+	}
+
+	return response
+}
+
+func (h *UserHandler) toUserPullRequestsResponse(user *entities.User, prs []entities.PullRequestShort) UserPullRequestsResponse {
 	response := UserPullRequestsResponse{
-		UserID:       userID,
-		PullRequests: make([]PullRequestReviewResponse, len(prs)),
+		UserID:         user.UserID,
+		OpenReviews:    user.OpenReviews,
+		MaxOpenReviews: user.ReviewCapacity,
+		PullRequests:   make([]PullRequestReviewResponse, len(prs)),
 	}
 
 	for i, pr := range prs {
@@ -52,3 +166,58 @@ func (h *UserHandler) toUserPullRequestsResponse(userID string, prs []entities.P
 
 	return response
 }
+
+func (h *UserHandler) toUserSkillsResponse(user *entities.User) UserSkillsResponse {
+	skills := user.Skills
+	if skills == nil {
+		skills = []string{}
+	}
+	return UserSkillsResponse{
+		UserID: user.UserID,
+		Skills: skills,
+	}
+}
+
+func (h *UserHandler) toUserTeamsResponse(userID string, memberships []*entities.TeamMembership) UserTeamsResponse {
+	response := UserTeamsResponse{
+		UserID: userID,
+		Teams:  make([]UserTeamResponse, len(memberships)),
+	}
+
+	for i, membership := range memberships {
+		if membership.IsPrimary {
+			response.PrimaryTeam = membership.TeamName
+		}
+		response.Teams[i] = UserTeamResponse{
+			TeamName:  membership.TeamName,
+			Role:      string(membership.Role),
+			IsPrimary: membership.IsPrimary,
+			JoinedAt:  membership.JoinedAt,
+		}
+	}
+
+	return response
+}
+
+func (h *UserHandler) toOutOfOfficeResponse(period *entities.OutOfOfficePeriod) OutOfOfficeResponse {
+	return OutOfOfficeResponse{
+		ID:       period.ID,
+		UserID:   period.UserID,
+		StartsAt: period.StartsAt,
+		EndsAt:   period.EndsAt,
+		Reason:   period.Reason,
+	}
+}
+
+func (h *UserHandler) toOutOfOfficeListResponse(userID string, periods []*entities.OutOfOfficePeriod) OutOfOfficeListResponse {
+	response := OutOfOfficeListResponse{
+		UserID:  userID,
+		Periods: make([]OutOfOfficeResponse, len(periods)),
+	}
+
+	for i, period := range periods {
+		response.Periods[i] = h.toOutOfOfficeResponse(period)
+	}
+
+	return response
+}
@@ -4,31 +4,37 @@ import (
 	"net/http"
 
 	"go-project/internal/application/usecases"
+	"go-project/internal/interfaces/httpapi/handlers/ownership"
 	"go-project/internal/interfaces/httpapi/handlers/pullrequests"
 	"go-project/internal/interfaces/httpapi/handlers/teams"
 	"go-project/internal/interfaces/httpapi/handlers/users"
 )
 
 type Server struct {
-	prHandler   *pullrequests.PullRequestHandler
-	teamHandler *teams.TeamHandler
-	userHandler *users.UserHandler
-	mux         *http.ServeMux
+	prHandler        *pullrequests.PullRequestHandler
+	teamHandler      *teams.TeamHandler
+	userHandler      *users.UserHandler
+	ownershipHandler *ownership.OwnershipHandler
+	mux              *http.ServeMux
 }
 
 func NewServer(
 	prUseCase *usecases.PullRequestUseCase,
 	teamUseCase *usecases.TeamUseCase,
 	userUseCase *usecases.UserUseCase,
+	ownershipUseCase *usecases.OwnershipUseCase,
+	slaUseCase *usecases.ReviewSLAUseCase,
 ) *Server {
 	prHandler := pullrequests.NewPullRequestHandler(prUseCase)
-	teamHandler := teams.NewTeamHandler(teamUseCase)
+	teamHandler := teams.NewTeamHandler(teamUseCase, slaUseCase)
 	userHandler := users.NewUserHandler(userUseCase, prUseCase)
+	ownershipHandler := ownership.NewOwnershipHandler(ownershipUseCase)
 	s := &Server{
-		prHandler:   prHandler,
-		teamHandler: teamHandler,
-		userHandler: userHandler,
-		mux:         http.NewServeMux(),
+		prHandler:        prHandler,
+		teamHandler:      teamHandler,
+		userHandler:      userHandler,
+		ownershipHandler: ownershipHandler,
+		mux:              http.NewServeMux(),
 	}
 
 	s.setupRoutes()
@@ -43,17 +49,59 @@ func (s *Server) setupRoutes() {
 
 	// Пулл-реквесты - делегируем хендлерам
 	s.mux.HandleFunc("POST /pullRequest/create", s.prHandler.PostPullRequestCreate)
+	s.mux.HandleFunc("POST /pullRequest/previewAssignment", s.prHandler.PostPullRequestPreviewAssignment)
+	s.mux.HandleFunc("POST /pullRequest/update", s.prHandler.PostPullRequestUpdate)
 	s.mux.HandleFunc("POST /pullRequest/merge", s.prHandler.PostPullRequestMerge)
+	s.mux.HandleFunc("POST /pullRequest/markReady", s.prHandler.PostPullRequestMarkReady)
+	s.mux.HandleFunc("POST /pullRequest/close", s.prHandler.PostPullRequestClose)
+	s.mux.HandleFunc("POST /pullRequest/reopen", s.prHandler.PostPullRequestReopen)
+	s.mux.HandleFunc("POST /pullRequest/addReviewer", s.prHandler.PostPullRequestAddReviewer)
+	s.mux.HandleFunc("POST /pullRequest/removeReviewer", s.prHandler.PostPullRequestRemoveReviewer)
+	s.mux.HandleFunc("POST /pullRequest/pinReviewer", s.prHandler.PostPullRequestPinReviewer)
 	s.mux.HandleFunc("POST /pullRequest/reassign", s.prHandler.PostPullRequestReassign)
+	s.mux.HandleFunc("POST /pullRequest/review", s.prHandler.PostPullRequestReview)
+	s.mux.HandleFunc("POST /pullRequest/dismissReview", s.prHandler.PostPullRequestDismissReview)
+	s.mux.HandleFunc("GET /pullRequest/get", s.prHandler.GetPullRequest)
+	s.mux.HandleFunc("GET /pullRequest/list", s.prHandler.GetPullRequestList)
+	s.mux.HandleFunc("GET /pullRequest/reviews", s.prHandler.GetReviews)
 	s.mux.HandleFunc("GET /pullRequest/userStats", s.prHandler.GetUserPRStats)
+	s.mux.HandleFunc("GET /pullRequest/assignmentLog", s.prHandler.GetAssignmentLog)
 
 	// Команды - делегируем хендлерам
 	s.mux.HandleFunc("POST /team/add", s.teamHandler.PostTeamAdd)
 	s.mux.HandleFunc("GET /team/get", s.teamHandler.GetTeamGet)
+	s.mux.HandleFunc("GET /team/list", s.teamHandler.GetTeamList)
+	s.mux.HandleFunc("POST /team/update", s.teamHandler.PostTeamUpdate)
+	s.mux.HandleFunc("POST /team/delete", s.teamHandler.PostTeamDelete)
+	s.mux.HandleFunc("POST /team/rename", s.teamHandler.PostTeamRename)
+	s.mux.HandleFunc("POST /team/addMember", s.teamHandler.PostTeamAddMember)
+	s.mux.HandleFunc("POST /team/removeMember", s.teamHandler.PostTeamRemoveMember)
+	s.mux.HandleFunc("GET /team/policy", s.teamHandler.GetTeamPolicy)
+	s.mux.HandleFunc("PUT /team/policy", s.teamHandler.PutTeamPolicy)
+	s.mux.HandleFunc("POST /team/deactivateMembers", s.teamHandler.PostTeamDeactivateMembers)
+	s.mux.HandleFunc("GET /team/escalations", s.teamHandler.GetTeamEscalations)
+	s.mux.HandleFunc("POST /team/rebalance", s.teamHandler.PostTeamRebalance)
 
 	// Пользователи - делегируем хендлерам
+	s.mux.HandleFunc("POST /users/add", s.userHandler.PostUsersAdd)
+	s.mux.HandleFunc("GET /users/get", s.userHandler.GetUsersGet)
+	s.mux.HandleFunc("GET /users/list", s.userHandler.GetUsersList)
+	s.mux.HandleFunc("POST /users/update", s.userHandler.PostUsersUpdate)
 	s.mux.HandleFunc("GET /users/getReview", s.userHandler.GetUsersGetReview)
 	s.mux.HandleFunc("POST /users/setIsActive", s.userHandler.PostUsersSetIsActive)
+	s.mux.HandleFunc("POST /users/setMaxOpenReviews", s.userHandler.PostUsersSetMaxOpenReviews)
+	s.mux.HandleFunc("POST /users/setWorkSchedule", s.userHandler.PostUsersSetWorkSchedule)
+	s.mux.HandleFunc("GET /users/skills", s.userHandler.GetUsersSkills)
+	s.mux.HandleFunc("POST /users/setSkills", s.userHandler.PostUsersSetSkills)
+	s.mux.HandleFunc("GET /users/teams", s.userHandler.GetUsersTeams)
+	s.mux.HandleFunc("POST /users/setPrimaryTeam", s.userHandler.PostUsersSetPrimaryTeam)
+	s.mux.HandleFunc("GET /users/ooo", s.userHandler.GetUsersOutOfOffice)
+	s.mux.HandleFunc("POST /users/ooo", s.userHandler.PostUsersOutOfOffice)
+	s.mux.HandleFunc("DELETE /users/ooo", s.userHandler.DeleteUsersOutOfOffice)
+
+	// Правила владения путями (CODEOWNERS)
+	s.mux.HandleFunc("POST /ownership/import", s.ownershipHandler.PostOwnershipImport)
+	s.mux.HandleFunc("GET /ownership/rules", s.ownershipHandler.GetOwnershipRules)
 }
 
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
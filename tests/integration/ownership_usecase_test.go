@@ -0,0 +1,109 @@
+package integration
+
+import (
+	"testing"
+
+	"go-project/internal/application/usecases"
+	"go-project/internal/domain/entities"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestOwnershipUseCaseIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite.Run(t, new(OwnershipUseCaseTestSuite))
+}
+
+type OwnershipUseCaseTestSuite struct {
+	IntegrationTestSuite
+}
+
+func (s *OwnershipUseCaseTestSuite) SetupTest() {
+	s.IntegrationTestSuite.SetupTest()
+
+	s.teamUC.CreateTeam(s.ctx, &entities.Team{
+		Name: "dev",
+		Members: []*entities.User{
+			{UserID: "author1", Username: "author1", IsActive: true},
+			{UserID: "reviewer1", Username: "reviewer1", IsActive: true},
+			{UserID: "reviewer2", Username: "reviewer2", IsActive: true},
+		},
+	})
+	s.teamUC.CreateTeam(s.ctx, &entities.Team{
+		Name: "dba",
+		Members: []*entities.User{
+			{UserID: "dba1", Username: "dba1", IsActive: true},
+		},
+	})
+}
+
+func (s *OwnershipUseCaseTestSuite) TestImportCodeowners_Success() {
+	content := `
+# Владельцы по умолчанию
+*          @org/dev
+*.sql      @org/dba   # миграции
+/docs/     @reviewer2
+`
+	rules, err := s.ownershipUC.ImportCodeowners(s.ctx, content)
+
+	s.NoError(err)
+	s.Len(rules, 3)
+	s.Equal([]string{"dba"}, rules[1].OwnerTeams)
+	s.Equal([]string{"reviewer2"}, rules[2].OwnerUsers)
+
+	stored, err := s.ownershipUC.GetRules(s.ctx)
+	s.NoError(err)
+	s.Len(stored, 3)
+}
+
+func (s *OwnershipUseCaseTestSuite) TestImportCodeowners_UnknownTeam() {
+	rules, err := s.ownershipUC.ImportCodeowners(s.ctx, "*.go @org/unknown")
+
+	s.Error(err)
+	s.Nil(rules)
+	s.Contains(err.Error(), "owner team unknown not found")
+}
+
+func (s *OwnershipUseCaseTestSuite) TestImportCodeowners_EmailOwner() {
+	rules, err := s.ownershipUC.ImportCodeowners(s.ctx, "*.go dev@example.com")
+
+	s.Error(err)
+	s.Nil(rules)
+	s.Contains(err.Error(), "unsupported owner")
+}
+
+func (s *OwnershipUseCaseTestSuite) TestImportCodeowners_UnsupportedPattern() {
+	for _, pattern := range []string{"!*.go", "src/[ab]/", "\\*.go"} {
+		rules, err := s.ownershipUC.ImportCodeowners(s.ctx, "*.sql @org/dba\n"+pattern+" @reviewer2")
+
+		s.Error(err, pattern)
+		s.Nil(rules)
+		s.Contains(err.Error(), "line 2")
+	}
+
+	stored, err := s.ownershipUC.GetRules(s.ctx)
+	s.NoError(err)
+	s.Empty(stored)
+}
+
+func (s *OwnershipUseCaseTestSuite) TestCreatePR_AssignsPathOwnersFirst() {
+	_, err := s.ownershipUC.ImportCodeowners(s.ctx, "*.sql @org/dba\n/docs/ @reviewer2")
+	s.NoError(err)
+
+	opts := usecases.CreatePROptions{Paths: []string{"migrations/001_init.sql"}}
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-1", "Add migration", opts)
+
+	s.NoError(err)
+	s.Len(pr.AssignedReviewers, 2)
+	s.Equal("dba1", pr.AssignedReviewers[0])
+
+	opts = usecases.CreatePROptions{Paths: []string{"docs/readme.md"}}
+	pr, err = s.prUC.CreatePR(s.ctx, "author1", "pr-2", "Update docs", opts)
+
+	s.NoError(err)
+	s.Len(pr.AssignedReviewers, 2)
+	s.Equal("reviewer2", pr.AssignedReviewers[0])
+}
@@ -1,9 +1,15 @@
 package integration
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"go-project/internal/application/usecases"
 	"go-project/internal/domain/entities"
+	"go-project/internal/domain/repositories"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -45,7 +51,7 @@ func (s *PullRequestUseCaseTestSuite) setupTestTeam() {
 }
 
 func (s *PullRequestUseCaseTestSuite) TestCreatePR_Success() {
-	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-123", "Test Pull Request")
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-123", "Test Pull Request", usecases.CreatePROptions{})
 
 	s.NoError(err)
 	s.NotNil(pr)
@@ -58,20 +64,348 @@ func (s *PullRequestUseCaseTestSuite) TestCreatePR_Success() {
 	for _, reviewer := range pr.AssignedReviewers {
 		s.NotEqual("author1", reviewer)
 		user, _ := s.userRepo.GetByID(s.ctx, reviewer)
-		s.False(user.IsActive, "Reviewer should be deactivated")
+		s.True(user.IsActive, "Assignment should not change availability")
+		s.Equal(1, user.OpenReviews)
 	}
 }
 
+// Отказывает в записи решений о назначении, имитируя сбой журнала
+type failingDecisionRepo struct {
+	repositories.PullRequestRepository
+}
+
+func (r *failingDecisionRepo) SaveAssignmentDecision(context.Context, *entities.AssignmentDecision) error {
+	return errors.New("connection reset")
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_NotCreatedWhenDecisionFails() {
+	prUC := usecases.NewPullRequestUseCase(&failingDecisionRepo{PullRequestRepository: s.prRepo},
+		s.teamRepo, s.userRepo, s.ruleRepo, s.db, entities.StrategyLeastOpenReviews, s.clock)
+
+	_, err := prUC.CreatePR(s.ctx, "author1", "pr-atomic", "Test PR", usecases.CreatePROptions{})
+	s.Require().Error(err)
+	_, err = s.prRepo.GetByID(s.ctx, "pr-atomic")
+	s.Error(err, "PR must not be created without its assignment decision")
+
+	// Повтор запроса создает PR, а не отвечает PR_EXISTS
+	_, err = s.prUC.CreatePR(s.ctx, "author1", "pr-atomic", "Test PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "pr-atomic")
+	s.Require().NoError(err)
+	s.Len(decisions, 1)
+}
+
 func (s *PullRequestUseCaseTestSuite) TestCreatePR_AuthorNotInTeam() {
-	pr, err := s.prUC.CreatePR(s.ctx, "unknown_user", "pr-456", "Test PR")
+	pr, err := s.prUC.CreatePR(s.ctx, "unknown_user", "pr-456", "Test PR", usecases.CreatePROptions{})
 
 	s.Error(err)
 	s.Nil(pr)
 	s.Contains(err.Error(), "team not found")
 }
 
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_UnknownStrategy() {
+	opts := usecases.CreatePROptions{Strategy: "alphabetical"}
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-321", "Test PR", opts)
+
+	s.Error(err)
+	s.Nil(pr)
+	s.Contains(err.Error(), "unknown reviewer strategy")
+}
+
+// Команда с одним ревьювером на PR, чтобы было видно порядок назначения
+func (s *PullRequestUseCaseTestSuite) createSingleReviewerTeam(name string, members []*entities.User) {
+	s.Require().NoError(s.teamUC.CreateTeam(s.ctx, &entities.Team{Name: name, Members: members}))
+
+	policy := entities.DefaultTeamPolicy(name)
+	policy.RequiredReviewers = 1
+	_, err := s.teamUC.UpdatePolicy(s.ctx, policy)
+	s.Require().NoError(err)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_RoundRobinRotatesOverEligible() {
+	s.createSingleReviewerTeam("Rotation", []*entities.User{
+		{UserID: "rr-author", Username: "rr-author", IsActive: true},
+		{UserID: "rr-1", Username: "rr-1", IsActive: true},
+		{UserID: "rr-2", Username: "rr-2", IsActive: true},
+		{UserID: "rr-3", Username: "rr-3", IsActive: false},
+		{UserID: "rr-4", Username: "rr-4", IsActive: true},
+	})
+
+	// Неактивный rr-3 пропускается, после последнего круг начинается заново
+	opts := usecases.CreatePROptions{Strategy: entities.StrategyRoundRobin}
+	for i, expected := range []string{"rr-1", "rr-2", "rr-4", "rr-1", "rr-2"} {
+		pr, err := s.prUC.CreatePR(s.ctx, "rr-author", fmt.Sprintf("pr-rr-%d", i), "Rotation PR", opts)
+		s.Require().NoError(err)
+		s.Equal([]string{expected}, pr.AssignedReviewers, "PR %d", i)
+	}
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_RandomPicksOnlyEligible() {
+	s.createSingleReviewerTeam("Lottery", []*entities.User{
+		{UserID: "rnd-author", Username: "rnd-author", IsActive: true},
+		{UserID: "rnd-1", Username: "rnd-1", IsActive: true},
+		{UserID: "rnd-2", Username: "rnd-2", IsActive: true},
+		{UserID: "rnd-inactive", Username: "rnd-inactive", IsActive: false},
+		{UserID: "rnd-full", Username: "rnd-full", IsActive: true},
+		{UserID: "rnd-away", Username: "rnd-away", IsActive: true},
+	})
+
+	// rnd-full исчерпал лимит ревью на открытом PR
+	limit := 1
+	_, err := s.userUC.SetMaxOpenReviews(s.ctx, "rnd-full", &limit)
+	s.Require().NoError(err)
+	_, err = s.prUC.CreatePR(s.ctx, "rnd-author", "pr-rnd-load", "Load", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	_, err = s.prUC.AddReviewer(s.ctx, "pr-rnd-load", "rnd-full", false)
+	s.Require().NoError(err)
+	_, err = s.userUC.AddOutOfOffice(s.ctx, "rnd-away", s.clock.now.Add(-time.Hour), s.clock.now.Add(time.Hour), "vacation")
+	s.Require().NoError(err)
+
+	// Закрываем каждый PR, чтобы загрузка не исключала подходящих кандидатов
+	opts := usecases.CreatePROptions{Strategy: entities.StrategyRandom}
+	for i := range 20 {
+		prID := fmt.Sprintf("pr-rnd-%d", i)
+		pr, err := s.prUC.CreatePR(s.ctx, "rnd-author", prID, "Random PR", opts)
+		s.Require().NoError(err)
+		s.Require().Len(pr.AssignedReviewers, 1)
+		s.Contains([]string{"rnd-1", "rnd-2"}, pr.AssignedReviewers[0])
+
+		_, err = s.prUC.ClosePR(s.ctx, prID)
+		s.Require().NoError(err)
+	}
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_SkipsReviewerAtCapacity() {
+	first, err := s.prUC.CreatePR(s.ctx, "author1", "pr-501", "First PR", usecases.CreatePROptions{})
+	s.NoError(err)
+	s.ElementsMatch([]string{"reviewer1", "reviewer2"}, first.AssignedReviewers)
+
+	limit := 1
+	_, err = s.userUC.SetMaxOpenReviews(s.ctx, "reviewer1", &limit)
+	s.NoError(err)
+
+	second, err := s.prUC.CreatePR(s.ctx, "author1", "pr-502", "Second PR", usecases.CreatePROptions{})
+	s.NoError(err)
+	s.Equal([]string{"reviewer2"}, second.AssignedReviewers)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_SkipsReviewerOutOfOffice() {
+	now := time.Now()
+	_, err := s.userUC.AddOutOfOffice(s.ctx, "reviewer1", now.Add(-time.Hour), now.Add(time.Hour), "vacation")
+	s.NoError(err)
+
+	// Будущий отпуск не мешает назначению
+	_, err = s.userUC.AddOutOfOffice(s.ctx, "reviewer2", now.Add(24*time.Hour), now.Add(48*time.Hour), "")
+	s.NoError(err)
+
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-503", "OOO PR", usecases.CreatePROptions{})
+
+	s.NoError(err)
+	s.Equal([]string{"reviewer2"}, pr.AssignedReviewers)
+
+	user, _ := s.userRepo.GetByID(s.ctx, "reviewer1")
+	s.True(user.IsActive, "OOO should not change availability flag")
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_PrefersReviewerInWorkingHours() {
+	_, err := s.teamUC.UpdatePolicy(s.ctx, &entities.TeamPolicy{
+		TeamName:           "Dev Team",
+		RequiredReviewers:  1,
+		PreferWorkingHours: true,
+	})
+	s.NoError(err)
+
+	// 22:00 по Москве: reviewer1 уже закончил, reviewer2 в Нью-Йорке еще работает
+	s.clock.now = time.Date(2025, 3, 10, 19, 0, 0, 0, time.UTC)
+	_, err = s.userUC.SetWorkSchedule(s.ctx, "reviewer1",
+		&entities.WorkSchedule{Timezone: "Europe/Moscow", Start: "09:00", End: "18:00"})
+	s.NoError(err)
+	_, err = s.userUC.SetWorkSchedule(s.ctx, "reviewer2",
+		&entities.WorkSchedule{Timezone: "America/New_York", Start: "09:00", End: "18:00"})
+	s.NoError(err)
+
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-604", "Late PR", usecases.CreatePROptions{})
+	s.NoError(err)
+	s.Equal([]string{"reviewer2"}, pr.AssignedReviewers)
+	s.True(pr.CreatedAt.Equal(s.clock.now))
+
+	// Ночью у обоих нерабочее время - назначаем любого доступного
+	s.clock.now = time.Date(2025, 3, 10, 3, 0, 0, 0, time.UTC)
+	pr, err = s.prUC.CreatePR(s.ctx, "author1", "pr-605", "Night PR", usecases.CreatePROptions{})
+	s.NoError(err)
+	s.Len(pr.AssignedReviewers, 1)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_UsesTeamPolicyReviewerCount() {
+	_, err := s.teamUC.UpdatePolicy(s.ctx, &entities.TeamPolicy{
+		TeamName:          "Dev Team",
+		RequiredReviewers: 1,
+	})
+	s.NoError(err)
+
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-601", "Single reviewer PR", usecases.CreatePROptions{})
+
+	s.NoError(err)
+	s.Len(pr.AssignedReviewers, 1)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_PrefersReviewerWithMatchingSkill() {
+	_, err := s.teamUC.UpdatePolicy(s.ctx, &entities.TeamPolicy{
+		TeamName:          "Dev Team",
+		RequiredReviewers: 1,
+	})
+	s.NoError(err)
+
+	_, err = s.userUC.SetSkills(s.ctx, "reviewer2", []string{"postgres"})
+	s.NoError(err)
+
+	opts := usecases.CreatePROptions{Labels: []string{"Postgres", "postgres"}}
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-602", "Migration PR", opts)
+
+	s.NoError(err)
+	s.Equal([]string{"reviewer2"}, pr.AssignedReviewers)
+	s.Equal([]string{"postgres"}, pr.Labels)
+
+	stored, _ := s.prRepo.GetByID(s.ctx, "pr-602")
+	s.Equal([]string{"postgres"}, stored.Labels)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_ReservesSeatForSkillFromFallbackTeam() {
+	s.Require().NoError(s.teamUC.CreateTeam(s.ctx, &entities.Team{
+		Name:    "DBA Team",
+		Members: []*entities.User{{UserID: "dba1", Username: "dba1", IsActive: true}},
+	}))
+	_, err := s.userUC.SetSkills(s.ctx, "dba1", []string{"postgres"})
+	s.Require().NoError(err)
+	_, err = s.ownershipUC.ImportCodeowners(s.ctx, "*.sql @reviewer1")
+	s.Require().NoError(err)
+
+	policy := &entities.TeamPolicy{
+		TeamName:          "Dev Team",
+		RequiredReviewers: 1,
+		CrossTeamFallback: true,
+		FallbackTeams:     []string{"DBA Team"},
+	}
+	_, err = s.teamUC.UpdatePolicy(s.ctx, policy)
+	s.Require().NoError(err)
+
+	// Владелец пути не занимает единственное место, в команде автора навыка нет
+	opts := usecases.CreatePROptions{Paths: []string{"db/001.sql"}, Labels: []string{"postgres"}}
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-skill-1", "Migration", opts)
+	s.Require().NoError(err)
+	s.Equal([]string{"dba1"}, pr.AssignedReviewers)
+	s.Equal([]string{"dba1"}, pr.FallbackReviewers)
+
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "pr-skill-1")
+	s.Require().NoError(err)
+	s.Contains(decisions[0].Candidates, entities.CandidateDecision{
+		UserID: "reviewer2", TeamName: "Dev Team", Source: entities.SourceAuthorTeam, Excluded: entities.ExcludedNoSkill,
+	})
+
+	// Остальные места по-прежнему достаются владельцам путей
+	policy.RequiredReviewers = 2
+	_, err = s.teamUC.UpdatePolicy(s.ctx, policy)
+	s.Require().NoError(err)
+
+	pr, err = s.prUC.CreatePR(s.ctx, "author1", "pr-skill-2", "Migration", opts)
+	s.Require().NoError(err)
+	s.Equal([]string{"reviewer1", "dba1"}, pr.AssignedReviewers)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_FillsSeatsFromFallbackTeam() {
+	s.teamUC.CreateTeam(s.ctx, &entities.Team{
+		Name: "Ops Team",
+		Members: []*entities.User{
+			{UserID: "ops1", Username: "ops1", IsActive: true},
+		},
+	})
+	_, err := s.teamUC.UpdatePolicy(s.ctx, &entities.TeamPolicy{
+		TeamName:          "Dev Team",
+		RequiredReviewers: 3,
+		CrossTeamFallback: true,
+		FallbackTeams:     []string{"Ops Team"},
+	})
+	s.NoError(err)
+
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-701", "Needs three reviewers", usecases.CreatePROptions{})
+
+	s.NoError(err)
+	s.ElementsMatch([]string{"reviewer1", "reviewer2", "ops1"}, pr.AssignedReviewers)
+	s.Equal([]string{"ops1"}, pr.FallbackReviewers)
+
+	stored, _ := s.prRepo.GetByID(s.ctx, "pr-701")
+	s.Equal([]string{"ops1"}, stored.FallbackReviewers)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_RequiresSeniorReviewer() {
+	team := &entities.Team{
+		Name: "Seniority Team",
+		Members: []*entities.User{
+			{UserID: "s-author", Username: "s-author", IsActive: true},
+			{UserID: "s-m1", Username: "s-m1", IsActive: true},
+			{UserID: "s-m2", Username: "s-m2", IsActive: true},
+			{UserID: "s-senior", Username: "s-senior", IsActive: true, Role: entities.RoleSenior},
+		},
+	}
+	s.Require().NoError(s.teamUC.CreateTeam(s.ctx, team))
+	_, err := s.teamUC.UpdatePolicy(s.ctx, &entities.TeamPolicy{
+		TeamName:          "Seniority Team",
+		RequiredReviewers: 2,
+		RequiredRole:      entities.RoleSenior,
+		MinRoleReviewers:  1,
+	})
+	s.Require().NoError(err)
+
+	pr, err := s.prUC.CreatePR(s.ctx, "s-author", "pr-senior", "Senior PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	s.Contains(pr.AssignedReviewers, "s-senior")
+	s.Len(pr.AssignedReviewers, 2)
+
+	// Единственного старшего некем заменить
+	_, _, err = s.prUC.ReassignReviewer(s.ctx, "pr-senior", "s-senior", "")
+	s.Error(err)
+	s.Contains(err.Error(), "NO_CANDIDATE")
+
+	member := pr.AssignedReviewers[0]
+	if member == "s-senior" {
+		member = pr.AssignedReviewers[1]
+	}
+	updated, newReviewer, err := s.prUC.ReassignReviewer(s.ctx, "pr-senior", member, "")
+	s.NoError(err)
+	s.NotEqual("s-senior", newReviewer)
+	s.Contains(updated.AssignedReviewers, "s-senior")
+
+	// Без доступных старших PR создается с обычными ревьюверами и ждет старшего
+	_, _, _, err = s.userUC.SetUserActive(s.ctx, "s-senior", false)
+	s.Require().NoError(err)
+	pr, err = s.prUC.CreatePR(s.ctx, "s-author", "pr-senior-2", "Senior PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"s-m1", "s-m2"}, pr.AssignedReviewers)
+	s.True(pr.NeedsReviewer)
+
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "pr-senior-2")
+	s.Require().NoError(err)
+	s.Require().Len(decisions, 1)
+	s.Equal(entities.RoleSenior, decisions[0].MissingRole)
+	s.Equal(1, decisions[0].MissingRoleReviewers)
+
+	stored, err := s.prUC.GetPR(s.ctx, "pr-senior-2")
+	s.Require().NoError(err)
+	s.True(stored.NeedsReviewer)
+
+	// Флаг снимается, когда старший назначен
+	_, _, _, err = s.userUC.SetUserActive(s.ctx, "s-senior", true)
+	s.Require().NoError(err)
+	updated, err = s.prUC.AddReviewer(s.ctx, "pr-senior-2", "s-senior", false)
+	s.Require().NoError(err)
+	stored, err = s.prUC.GetPR(s.ctx, "pr-senior-2")
+	s.Require().NoError(err)
+	s.False(stored.NeedsReviewer)
+	s.Contains(updated.AssignedReviewers, "s-senior")
+}
+
 func (s *PullRequestUseCaseTestSuite) TestMergePR_Success() {
-	pr, _ := s.prUC.CreatePR(s.ctx, "author1", "pr-789", "PR to Merge")
+	pr, _ := s.prUC.CreatePR(s.ctx, "author1", "pr-789", "PR to Merge", usecases.CreatePROptions{})
 	reviewers := pr.AssignedReviewers
 
 	mergedPR, err := s.prUC.MergePR(s.ctx, "pr-789")
@@ -83,15 +417,236 @@ func (s *PullRequestUseCaseTestSuite) TestMergePR_Success() {
 
 	for _, reviewer := range reviewers {
 		user, _ := s.userRepo.GetByID(s.ctx, reviewer)
-		s.True(user.IsActive, "Reviewer should be reactivated after merge")
+		s.True(user.IsActive)
+		s.Equal(0, user.OpenReviews, "Merged PR should not count as open review")
 	}
 }
 
+func (s *PullRequestUseCaseTestSuite) TestMergePR_KeepsDeactivatedReviewerInactive() {
+	pr, _ := s.prUC.CreatePR(s.ctx, "author1", "pr-790", "PR to Merge", usecases.CreatePROptions{})
+	reviewer := pr.AssignedReviewers[0]
+
+	_, _, _, err := s.userUC.SetUserActive(s.ctx, reviewer, false)
+	s.NoError(err)
+
+	_, err = s.prUC.MergePR(s.ctx, "pr-790")
+	s.NoError(err)
+
+	user, _ := s.userRepo.GetByID(s.ctx, reviewer)
+	s.False(user.IsActive, "Merge should not reactivate a deactivated reviewer")
+}
+
+func (s *PullRequestUseCaseTestSuite) TestSetUserActive_ReassignsOpenReviews() {
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-791", "Open PR", usecases.CreatePROptions{})
+	s.NoError(err)
+
+	_, _, _, err = s.userUC.SetUserActive(s.ctx, "reviewer3", true)
+	s.NoError(err)
+
+	_, _, summary, err := s.userUC.SetUserActive(s.ctx, "reviewer1", false)
+	s.NoError(err)
+	s.Equal([]usecases.ReviewerReplacement{
+		{PullRequestID: "pr-791", OldReviewerID: "reviewer1", NewReviewerID: "reviewer3"},
+	}, summary.Reassigned)
+	s.Empty(summary.Unassignable)
+
+	pr, err := s.prRepo.GetByID(s.ctx, "pr-791")
+	s.NoError(err)
+	s.ElementsMatch([]string{"reviewer2", "reviewer3"}, pr.AssignedReviewers)
+	s.False(pr.NeedsReviewer)
+
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "pr-791")
+	s.NoError(err)
+	s.Len(decisions, 2)
+	s.Equal("reviewer1", decisions[1].ReplacedUserID)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestSetUserActive_FlagsPRWithoutCandidate() {
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-792", "Open PR", usecases.CreatePROptions{})
+	s.NoError(err)
+
+	// reviewer3 неактивен, reviewer2 уже назначен - заменить reviewer1 некем
+	_, _, summary, err := s.userUC.SetUserActive(s.ctx, "reviewer1", false)
+	s.NoError(err)
+	s.Empty(summary.Reassigned)
+	s.Equal([]usecases.ReviewerReplacement{
+		{PullRequestID: "pr-792", OldReviewerID: "reviewer1"},
+	}, summary.Unassignable)
+
+	pr, err := s.prRepo.GetByID(s.ctx, "pr-792")
+	s.NoError(err)
+	s.Contains(pr.AssignedReviewers, "reviewer1")
+	s.True(pr.NeedsReviewer)
+
+	// Как только появляется кандидат, ручная замена снимает флаг
+	_, _, _, err = s.userUC.SetUserActive(s.ctx, "reviewer3", true)
+	s.NoError(err)
+
+	pr, newReviewer, err := s.prUC.ReassignReviewer(s.ctx, "pr-792", "reviewer1", "")
+	s.NoError(err)
+	s.Equal("reviewer3", newReviewer)
+	s.False(pr.NeedsReviewer)
+}
+
+func (s *PullRequestUseCaseTestSuite) setMinApprovals(minApprovals int) {
+	policy := entities.DefaultTeamPolicy("Dev Team")
+	policy.MinApprovals = minApprovals
+	_, err := s.teamUC.UpdatePolicy(s.ctx, policy)
+	s.Require().NoError(err)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestCreatePR_KeepsTeamAfterPrimaryTeamChange() {
+	s.setMinApprovals(1)
+	s.Require().NoError(s.teamUC.CreateTeam(s.ctx, &entities.Team{
+		Name:    "Infra Team",
+		Members: []*entities.User{{UserID: "infra1", Username: "infra1", IsActive: true}},
+	}))
+	_, err := s.teamUC.AddMember(s.ctx, "Infra Team", "author1", "")
+	s.Require().NoError(err)
+
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-team-1", "Dev PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	s.Equal("Dev Team", pr.TeamName)
+
+	// Смена основной команды влияет только на новые PR
+	_, err = s.userUC.SetPrimaryTeam(s.ctx, "author1", "Infra Team")
+	s.Require().NoError(err)
+
+	other, err := s.prUC.CreatePR(s.ctx, "author1", "pr-team-2", "Infra PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	s.Equal("Infra Team", other.TeamName)
+	s.Equal([]string{"infra1"}, other.AssignedReviewers)
+
+	prs, _, err := s.prUC.ListPRs(s.ctx, entities.PullRequestListFilter{TeamName: "Dev Team"}, "")
+	s.NoError(err)
+	s.Require().Len(prs, 1)
+	s.Equal("pr-team-1", prs[0].ID)
+	s.Equal("Dev Team", prs[0].TeamName)
+
+	// Мерж по-прежнему требует одобрения по политике Dev Team
+	_, err = s.prUC.MergePR(s.ctx, "pr-team-1")
+	s.Error(err)
+	s.Contains(err.Error(), "MERGE_BLOCKED")
+
+	// Замена ищется в Dev Team, где свободных нет, а не среди infra1 новой основной команды автора
+	_, _, err = s.prUC.ReassignReviewer(s.ctx, "pr-team-1", pr.AssignedReviewers[0], "")
+	s.Error(err)
+	s.Contains(err.Error(), "NO_CANDIDATE")
+}
+
+func (s *PullRequestUseCaseTestSuite) TestSubmitReview_NotAssigned() {
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-793", "Reviewed PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	review, err := s.prUC.SubmitReview(s.ctx, "pr-793", "reviewer3", entities.ReviewApproved, "")
+	s.Error(err)
+	s.Nil(review)
+	s.Contains(err.Error(), "NOT_ASSIGNED")
+
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-793", "reviewer1", "LGTM", "")
+	s.Error(err)
+	s.Contains(err.Error(), "INVALID_REQUEST")
+}
+
+func (s *PullRequestUseCaseTestSuite) TestMergePR_RequiresApprovals() {
+	s.setMinApprovals(2)
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-794", "Reviewed PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-794", "reviewer1", entities.ReviewApproved, "LGTM")
+	s.NoError(err)
+
+	_, err = s.prUC.MergePR(s.ctx, "pr-794")
+	s.Error(err)
+	s.Contains(err.Error(), "MERGE_BLOCKED")
+
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-794", "reviewer2", entities.ReviewApproved, "")
+	s.NoError(err)
+
+	pr, err := s.prUC.MergePR(s.ctx, "pr-794")
+	s.NoError(err)
+	s.Equal(entities.StatusMerged, pr.Status)
+
+	reviews, status, err := s.prUC.GetReviews(s.ctx, "pr-794")
+	s.NoError(err)
+	s.Len(reviews, 2)
+	s.ElementsMatch([]string{"reviewer1", "reviewer2"}, status.ApprovedBy)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestMergePR_BlockedByChangesRequested() {
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-795", "Reviewed PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-795", "reviewer1", entities.ReviewChangesRequested, "fix tests")
+	s.NoError(err)
+	// Комментарий не снимает запрос изменений
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-795", "reviewer1", entities.ReviewCommented, "pushed?")
+	s.NoError(err)
+
+	_, err = s.prUC.MergePR(s.ctx, "pr-795")
+	s.Error(err)
+	s.Contains(err.Error(), "changes requested by reviewer1")
+
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-795", "reviewer1", entities.ReviewApproved, "")
+	s.NoError(err)
+
+	_, err = s.prUC.MergePR(s.ctx, "pr-795")
+	s.NoError(err)
+
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-795", "reviewer2", entities.ReviewApproved, "")
+	s.Error(err)
+	s.Contains(err.Error(), "PR_MERGED")
+}
+
+func (s *PullRequestUseCaseTestSuite) TestMergePR_RemovedReviewerStillBlocksUntilDismissed() {
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-796", "Reviewed PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-796", "reviewer1", entities.ReviewChangesRequested, "fix tests")
+	s.Require().NoError(err)
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-796", "reviewer2", entities.ReviewApproved, "")
+	s.Require().NoError(err)
+
+	// Снятие ревьювера не отменяет его запрос изменений
+	_, err = s.prUC.RemoveReviewer(s.ctx, "pr-796", "reviewer1")
+	s.Require().NoError(err)
+
+	_, err = s.prUC.MergePR(s.ctx, "pr-796")
+	s.Error(err)
+	s.Contains(err.Error(), "changes requested by reviewer1")
+
+	_, status, err := s.prUC.GetReviews(s.ctx, "pr-796")
+	s.NoError(err)
+	s.Equal([]string{"reviewer1"}, status.ChangesRequestedBy)
+
+	// Отменить можно только явно, с автором отмены и причиной
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-796", "reviewer2", entities.ReviewDismissed, "")
+	s.Error(err)
+	_, err = s.prUC.DismissReview(s.ctx, "pr-796", "reviewer1", "author1", "")
+	s.Error(err)
+	_, err = s.prUC.DismissReview(s.ctx, "pr-796", "reviewer2", "author1", "not blocking")
+	s.Error(err)
+
+	dismissal, err := s.prUC.DismissReview(s.ctx, "pr-796", "reviewer1", "author1", "reviewer1 left the team")
+	s.Require().NoError(err)
+	s.Equal(entities.ReviewDismissed, dismissal.State)
+	s.Equal("author1", dismissal.DismissedBy)
+
+	_, err = s.prUC.MergePR(s.ctx, "pr-796")
+	s.NoError(err)
+
+	reviews, status, err := s.prUC.GetReviews(s.ctx, "pr-796")
+	s.NoError(err)
+	s.Len(reviews, 3)
+	s.Equal("author1", reviews[2].DismissedBy)
+	s.Empty(status.ChangesRequestedBy)
+}
+
 func (s *PullRequestUseCaseTestSuite) TestReassignReviewer_Success() {
-	pr, _ := s.prUC.CreatePR(s.ctx, "author1", "pr-999", "PR for Reassignment")
+	pr, _ := s.prUC.CreatePR(s.ctx, "author1", "pr-999", "PR for Reassignment", usecases.CreatePROptions{})
 	oldReviewer := pr.AssignedReviewers[0]
 
-	updatedPR, newReviewer, err := s.prUC.ReassignReviewer(s.ctx, "pr-999", oldReviewer)
+	updatedPR, newReviewer, err := s.prUC.ReassignReviewer(s.ctx, "pr-999", oldReviewer, "")
 
 	if err != nil {
 		s.T().Logf("ReassignReviewer failed: %v", err)
@@ -104,3 +659,435 @@ func (s *PullRequestUseCaseTestSuite) TestReassignReviewer_Success() {
 	s.Contains(updatedPR.AssignedReviewers, newReviewer)
 	s.NotContains(updatedPR.AssignedReviewers, oldReviewer)
 }
+
+func (s *PullRequestUseCaseTestSuite) TestMarkReady_AssignsReviewers() {
+	draft, err := s.prUC.CreatePR(s.ctx, "author1", "pr-d1", "Draft PR", usecases.CreatePROptions{Draft: true})
+	s.Require().NoError(err)
+	s.Equal(entities.StatusDraft, draft.Status)
+	s.Empty(draft.AssignedReviewers)
+
+	user, _ := s.userRepo.GetByID(s.ctx, "reviewer1")
+	s.Equal(0, user.OpenReviews, "Draft should not load reviewers")
+
+	_, err = s.prUC.MergePR(s.ctx, "pr-d1")
+	s.Error(err)
+	s.Contains(err.Error(), "INVALID_TRANSITION")
+
+	ready, err := s.prUC.MarkReady(s.ctx, "pr-d1", "")
+	s.NoError(err)
+	s.Equal(entities.StatusOpen, ready.Status)
+	s.ElementsMatch([]string{"reviewer1", "reviewer2"}, ready.AssignedReviewers)
+
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "pr-d1")
+	s.NoError(err)
+	s.Require().Len(decisions, 1)
+	s.Equal(entities.ActionReady, decisions[0].Action)
+
+	_, err = s.prUC.MarkReady(s.ctx, "pr-d1", "")
+	s.Error(err)
+	s.Contains(err.Error(), "INVALID_TRANSITION")
+}
+
+func (s *PullRequestUseCaseTestSuite) TestMarkReady_StaysDraftWhenDecisionFails() {
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-d2", "Draft PR", usecases.CreatePROptions{Draft: true})
+	s.Require().NoError(err)
+
+	prUC := usecases.NewPullRequestUseCase(&failingDecisionRepo{PullRequestRepository: s.prRepo},
+		s.teamRepo, s.userRepo, s.ruleRepo, s.db, entities.StrategyLeastOpenReviews, s.clock)
+	_, err = prUC.MarkReady(s.ctx, "pr-d2", "")
+	s.Require().Error(err)
+
+	stored, err := s.prUC.GetPR(s.ctx, "pr-d2")
+	s.Require().NoError(err)
+	s.Equal(entities.StatusDraft, stored.Status)
+	s.Empty(stored.AssignedReviewers)
+
+	ready, err := s.prUC.MarkReady(s.ctx, "pr-d2", "")
+	s.Require().NoError(err)
+	s.Equal(entities.StatusOpen, ready.Status)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestClosePR_FreesReviewersUntilReopen() {
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-c1", "PR to Close", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	_, err = s.prUC.ReopenPR(s.ctx, "pr-c1")
+	s.Error(err)
+	s.Contains(err.Error(), "INVALID_TRANSITION")
+
+	closed, err := s.prUC.ClosePR(s.ctx, "pr-c1")
+	s.NoError(err)
+	s.Equal(entities.StatusClosed, closed.Status)
+
+	for _, reviewer := range pr.AssignedReviewers {
+		user, _ := s.userRepo.GetByID(s.ctx, reviewer)
+		s.Equal(0, user.OpenReviews, "Closed PR should not count as open review")
+	}
+
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-c1", pr.AssignedReviewers[0], entities.ReviewApproved, "")
+	s.Error(err)
+	s.Contains(err.Error(), "INVALID_TRANSITION")
+
+	reopened, err := s.prUC.ReopenPR(s.ctx, "pr-c1")
+	s.NoError(err)
+	s.Equal(entities.StatusOpen, reopened.Status)
+	s.ElementsMatch(pr.AssignedReviewers, reopened.AssignedReviewers)
+
+	for _, reviewer := range pr.AssignedReviewers {
+		user, _ := s.userRepo.GetByID(s.ctx, reviewer)
+		s.Equal(1, user.OpenReviews)
+	}
+}
+
+func (s *PullRequestUseCaseTestSuite) TestClosePR_Merged() {
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-c2", "Merged PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	_, err = s.prUC.MergePR(s.ctx, "pr-c2")
+	s.Require().NoError(err)
+
+	_, err = s.prUC.ClosePR(s.ctx, "pr-c2")
+	s.Error(err)
+	s.Contains(err.Error(), "PR_MERGED")
+}
+
+func (s *PullRequestUseCaseTestSuite) TestAddReviewer_PinnedSurvivesDeactivation() {
+	s.Require().NoError(s.userRepo.Create(s.ctx, &entities.User{UserID: "expert", Username: "expert", IsActive: true}))
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-m1", "Manual PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	pr, err := s.prUC.AddReviewer(s.ctx, "pr-m1", "expert", true)
+	s.NoError(err)
+	s.ElementsMatch([]string{"reviewer1", "reviewer2", "expert"}, pr.AssignedReviewers)
+	s.Equal([]string{"expert"}, pr.PinnedReviewers)
+
+	_, _, summary, err := s.userUC.SetUserActive(s.ctx, "expert", false)
+	s.NoError(err)
+	s.Empty(summary.Reassigned)
+	s.Require().Len(summary.Unassignable, 1)
+	s.Equal("pr-m1", summary.Unassignable[0].PullRequestID)
+
+	stored, _ := s.prRepo.GetByID(s.ctx, "pr-m1")
+	s.Contains(stored.AssignedReviewers, "expert")
+	s.Equal([]string{"expert"}, stored.PinnedReviewers)
+	s.True(stored.NeedsReviewer)
+
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "pr-m1")
+	s.NoError(err)
+	s.Require().Len(decisions, 2)
+	s.Equal(entities.ActionAdd, decisions[1].Action)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestAddReviewer_Validation() {
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-m2", "Manual PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	_, err = s.prUC.AddReviewer(s.ctx, "pr-m2", "author1", false)
+	s.Error(err)
+	s.Contains(err.Error(), "INVALID_REQUEST")
+
+	_, err = s.prUC.AddReviewer(s.ctx, "pr-m2", "ghost", false)
+	s.Error(err)
+	s.Contains(err.Error(), "NOT_FOUND")
+
+	_, err = s.prUC.ClosePR(s.ctx, "pr-m2")
+	s.Require().NoError(err)
+	_, err = s.prUC.AddReviewer(s.ctx, "pr-m2", "reviewer1", false)
+	s.Error(err)
+	s.Contains(err.Error(), "INVALID_TRANSITION")
+}
+
+func (s *PullRequestUseCaseTestSuite) TestRemoveReviewer_WithoutReplacement() {
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-m3", "Manual PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	pr, err := s.prUC.PinReviewer(s.ctx, "pr-m3", "reviewer1", true)
+	s.NoError(err)
+	s.Equal([]string{"reviewer1"}, pr.PinnedReviewers)
+
+	pr, err = s.prUC.RemoveReviewer(s.ctx, "pr-m3", "reviewer1")
+	s.NoError(err)
+	s.Equal([]string{"reviewer2"}, pr.AssignedReviewers)
+	s.Empty(pr.PinnedReviewers)
+
+	_, err = s.prUC.RemoveReviewer(s.ctx, "pr-m3", "reviewer1")
+	s.Error(err)
+	s.Contains(err.Error(), "NOT_ASSIGNED")
+}
+
+func (s *PullRequestUseCaseTestSuite) TestGetAssignmentLog_RecordsDecisions() {
+	_, err := s.userUC.AddOutOfOffice(s.ctx, "reviewer2", s.clock.now.Add(-time.Hour), s.clock.now.Add(time.Hour), "")
+	s.NoError(err)
+
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-801", "Logged PR", usecases.CreatePROptions{})
+	s.NoError(err)
+	s.Equal([]string{"reviewer1"}, pr.AssignedReviewers)
+
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "pr-801")
+	s.NoError(err)
+	s.Len(decisions, 1)
+
+	decision := decisions[0]
+	s.Equal(entities.ActionCreate, decision.Action)
+	s.Equal(entities.StrategyLeastOpenReviews, decision.Strategy)
+
+	byUser := make(map[string]entities.CandidateDecision)
+	for _, candidate := range decision.Candidates {
+		byUser[candidate.UserID] = candidate
+	}
+	s.Equal(entities.ExcludedAuthor, byUser["author1"].Excluded)
+	s.Equal(entities.ExcludedOutOfOffice, byUser["reviewer2"].Excluded)
+	s.Equal(entities.ExcludedInactive, byUser["reviewer3"].Excluded)
+	s.True(byUser["reviewer1"].Selected)
+	s.Equal("least_open_reviews", byUser["reviewer1"].Rule)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestGetAssignmentLog_RecordsReassignment() {
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-802", "Logged PR", usecases.CreatePROptions{})
+	s.NoError(err)
+
+	// Единственный свободный кандидат - reviewer3, включаем его
+	_, _, _, err = s.userUC.SetUserActive(s.ctx, "reviewer3", true)
+	s.NoError(err)
+
+	_, newReviewer, err := s.prUC.ReassignReviewer(s.ctx, "pr-802", pr.AssignedReviewers[0], "")
+	s.NoError(err)
+	s.Equal("reviewer3", newReviewer)
+
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "pr-802")
+	s.NoError(err)
+	s.Len(decisions, 2)
+	s.Equal(entities.ActionReassign, decisions[1].Action)
+	s.Equal(pr.AssignedReviewers[0], decisions[1].ReplacedUserID)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestGetAssignmentLog_RecordsFailedReassignment() {
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-803", "Logged PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	// reviewer3 неактивен, остальные уже назначены
+	_, _, err = s.prUC.ReassignReviewer(s.ctx, "pr-803", pr.AssignedReviewers[0], "")
+	s.Require().Error(err)
+	s.Contains(err.Error(), "NO_CANDIDATE")
+
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "pr-803")
+	s.Require().NoError(err)
+	s.Require().Len(decisions, 2)
+	failed := decisions[1]
+	s.Equal(entities.ActionReassign, failed.Action)
+	s.Equal(pr.AssignedReviewers[0], failed.ReplacedUserID)
+	s.NotEmpty(failed.Candidates)
+	for _, candidate := range failed.Candidates {
+		s.False(candidate.Selected)
+		s.NotEmpty(candidate.Excluded)
+	}
+	s.Contains(failed.Candidates, entities.CandidateDecision{
+		UserID: "reviewer3", TeamName: "Dev Team", Source: entities.SourceAuthorTeam, Excluded: entities.ExcludedInactive,
+	})
+
+	// Состав ревьюверов не изменился
+	stored, err := s.prRepo.GetByID(s.ctx, "pr-803")
+	s.Require().NoError(err)
+	s.ElementsMatch(pr.AssignedReviewers, stored.AssignedReviewers)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestGetAssignmentLog_PRNotFound() {
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "missing-pr")
+
+	s.Error(err)
+	s.Nil(decisions)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestPreviewAssignment_MatchesCreateWithoutWrites() {
+	opts := usecases.CreatePROptions{Strategy: entities.StrategyRoundRobin}
+
+	plan, err := s.prUC.PreviewAssignment(s.ctx, "author1", opts)
+	s.NoError(err)
+	s.Equal("Dev Team", plan.TeamName)
+	s.Equal([]string{"reviewer1", "reviewer2"}, plan.Reviewers)
+	s.NotEmpty(plan.Candidates)
+
+	// Предпросмотр не создает PR, не двигает курсор и не меняет загрузку
+	cursor, err := s.teamRepo.GetReviewerCursor(s.ctx, "Dev Team")
+	s.NoError(err)
+	s.Empty(cursor)
+	user, _ := s.userRepo.GetByID(s.ctx, "reviewer1")
+	s.Equal(0, user.OpenReviews)
+
+	pr, err := s.prUC.CreatePR(s.ctx, "author1", "pr-901", "After preview", opts)
+	s.NoError(err)
+	s.Equal(plan.Reviewers, pr.AssignedReviewers)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestListPRs_FiltersAndStableCursor() {
+	start := s.clock.now
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-list-1", "First", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	// Два PR в один момент различаются по id
+	s.clock.now = start.Add(time.Hour)
+	_, err = s.prUC.CreatePR(s.ctx, "author1", "pr-list-2", "Draft", usecases.CreatePROptions{Draft: true})
+	s.Require().NoError(err)
+	third, err := s.prUC.CreatePR(s.ctx, "author1", "pr-list-3", "Third", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	prs, cursor, err := s.prUC.ListPRs(s.ctx, entities.PullRequestListFilter{Descending: true, Limit: 2}, "")
+	s.Require().NoError(err)
+	s.Require().Len(prs, 2)
+	s.Equal("pr-list-3", prs[0].ID)
+	s.Equal("pr-list-2", prs[1].ID)
+	s.NotEmpty(cursor)
+
+	// Курсор действует только с тем порядком, с которым выдан
+	_, _, err = s.prUC.ListPRs(s.ctx, entities.PullRequestListFilter{Limit: 2}, cursor)
+	s.Error(err)
+	s.Contains(err.Error(), "INVALID_REQUEST")
+
+	// Новый PR появляется в начале списка и не сдвигает следующую страницу
+	s.clock.now = start.Add(2 * time.Hour)
+	_, err = s.prUC.CreatePR(s.ctx, "author1", "pr-list-4", "Fourth", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	prs, cursor, err = s.prUC.ListPRs(s.ctx, entities.PullRequestListFilter{Descending: true, Limit: 2}, cursor)
+	s.Require().NoError(err)
+	s.Require().Len(prs, 1)
+	s.Equal("pr-list-1", prs[0].ID)
+	s.Empty(cursor)
+
+	prs, _, err = s.prUC.ListPRs(s.ctx, entities.PullRequestListFilter{NoReviewers: true}, "")
+	s.Require().NoError(err)
+	s.Require().Len(prs, 1)
+	s.Equal("pr-list-2", prs[0].ID)
+
+	from, to := start.Add(30*time.Minute), start.Add(90*time.Minute)
+	prs, _, err = s.prUC.ListPRs(s.ctx, entities.PullRequestListFilter{
+		Status:      entities.StatusOpen,
+		ReviewerID:  third.AssignedReviewers[0],
+		TeamName:    "Dev Team",
+		CreatedFrom: &from,
+		CreatedTo:   &to,
+	}, "")
+	s.Require().NoError(err)
+	s.Require().Len(prs, 1)
+	s.Equal("pr-list-3", prs[0].ID)
+	s.ElementsMatch(third.AssignedReviewers, prs[0].AssignedReviewers)
+
+	_, _, err = s.prUC.ListPRs(s.ctx, entities.PullRequestListFilter{Status: "UNKNOWN"}, "")
+	s.Error(err)
+	_, _, err = s.prUC.ListPRs(s.ctx, entities.PullRequestListFilter{}, "not-a-cursor")
+	s.Error(err)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestLargePR_GetsMoreReviewers() {
+	team := &entities.Team{
+		Name: "Big PRs",
+		Members: []*entities.User{
+			{UserID: "big-author", Username: "big-author", IsActive: true},
+			{UserID: "big-1", Username: "big-1", IsActive: true},
+			{UserID: "big-2", Username: "big-2", IsActive: true},
+			{UserID: "big-3", Username: "big-3", IsActive: true},
+			{UserID: "big-4", Username: "big-4", IsActive: true},
+		},
+	}
+	s.Require().NoError(s.teamUC.CreateTeam(s.ctx, team))
+
+	policy := entities.DefaultTeamPolicy("Big PRs")
+	policy.LargePRLines = 500
+	policy.LargePRReviewers = 1
+	_, err := s.teamUC.UpdatePolicy(s.ctx, policy)
+	s.Error(err, "large_pr_reviewers below required_reviewers")
+
+	policy.LargePRReviewers = 3
+	_, err = s.teamUC.UpdatePolicy(s.ctx, policy)
+	s.Require().NoError(err)
+
+	small, err := s.prUC.CreatePR(s.ctx, "big-author", "pr-small", "Small", usecases.CreatePROptions{
+		Metadata: entities.PullRequestMetadata{LinesAdded: 10, LinesRemoved: 2},
+	})
+	s.Require().NoError(err)
+	s.Len(small.AssignedReviewers, 2)
+
+	metadata := entities.PullRequestMetadata{
+		Description:  "Rewrite storage layer",
+		Repository:   "acme/storage",
+		URL:          "https://git.example.com/acme/storage/pull/7",
+		SourceBranch: "feature/storage",
+		TargetBranch: "main",
+		LinesAdded:   400,
+		LinesRemoved: 100,
+	}
+	large, err := s.prUC.CreatePR(s.ctx, "big-author", "pr-large", "Large", usecases.CreatePROptions{Metadata: metadata})
+	s.Require().NoError(err)
+	s.Len(large.AssignedReviewers, 3)
+
+	stored, err := s.prUC.GetPR(s.ctx, "pr-large")
+	s.Require().NoError(err)
+	s.Equal(metadata, stored.PullRequestMetadata)
+
+	// Размер черновика, измененный до markReady, учитывается при назначении
+	_, err = s.prUC.CreatePR(s.ctx, "big-author", "pr-grown", "Grown", usecases.CreatePROptions{Draft: true})
+	s.Require().NoError(err)
+	lines := 600
+	_, err = s.prUC.UpdatePR(s.ctx, "pr-grown", usecases.PullRequestUpdate{LinesAdded: &lines})
+	s.Require().NoError(err)
+
+	ready, err := s.prUC.MarkReady(s.ctx, "pr-grown", "")
+	s.Require().NoError(err)
+	s.Len(ready.AssignedReviewers, 3)
+
+	// OPEN PR, выросший больше large_pr_lines, добирает недостающего ревьювера
+	resized, err := s.prUC.UpdatePR(s.ctx, "pr-small", usecases.PullRequestUpdate{LinesAdded: &lines})
+	s.Require().NoError(err)
+	s.Len(resized.AssignedReviewers, 3)
+	s.Subset(resized.AssignedReviewers, small.AssignedReviewers)
+	s.NotContains(resized.AssignedReviewers, "big-author")
+
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "pr-small")
+	s.Require().NoError(err)
+	s.Require().NotEmpty(decisions)
+	s.Equal(entities.ActionResize, decisions[len(decisions)-1].Action)
+
+	// Повторное обновление без роста не меняет состав
+	again, err := s.prUC.UpdatePR(s.ctx, "pr-small", usecases.PullRequestUpdate{LinesAdded: &lines})
+	s.Require().NoError(err)
+	s.ElementsMatch(resized.AssignedReviewers, again.AssignedReviewers)
+
+	// Черновик при росте не получает ревьюверов до markReady
+	_, err = s.prUC.CreatePR(s.ctx, "big-author", "pr-draft-grown", "Draft", usecases.CreatePROptions{Draft: true})
+	s.Require().NoError(err)
+	draft, err := s.prUC.UpdatePR(s.ctx, "pr-draft-grown", usecases.PullRequestUpdate{LinesAdded: &lines})
+	s.Require().NoError(err)
+	s.Empty(draft.AssignedReviewers)
+}
+
+func (s *PullRequestUseCaseTestSuite) TestUpdatePR_ChangesOnlyGivenFields() {
+	_, err := s.prUC.CreatePR(s.ctx, "author1", "pr-meta", "Old name", usecases.CreatePROptions{
+		Metadata: entities.PullRequestMetadata{Repository: "acme/app", LinesAdded: 5},
+	})
+	s.Require().NoError(err)
+
+	name, description := "New name", "Details"
+	updated, err := s.prUC.UpdatePR(s.ctx, "pr-meta", usecases.PullRequestUpdate{Name: &name, Description: &description})
+	s.Require().NoError(err)
+	s.Equal("New name", updated.Name)
+	s.Equal("Details", updated.Description)
+	s.Equal("acme/app", updated.Repository)
+	s.Equal(5, updated.LinesAdded)
+
+	stored, err := s.prUC.GetPR(s.ctx, "pr-meta")
+	s.Require().NoError(err)
+	s.Equal(updated.PullRequestMetadata, stored.PullRequestMetadata)
+	s.ElementsMatch(updated.AssignedReviewers, stored.AssignedReviewers)
+
+	badURL, negative, empty := "ftp://example.com/pr", -1, ""
+	_, err = s.prUC.UpdatePR(s.ctx, "pr-meta", usecases.PullRequestUpdate{URL: &badURL})
+	s.Error(err)
+	_, err = s.prUC.UpdatePR(s.ctx, "pr-meta", usecases.PullRequestUpdate{LinesRemoved: &negative})
+	s.Error(err)
+	_, err = s.prUC.UpdatePR(s.ctx, "pr-meta", usecases.PullRequestUpdate{Name: &empty})
+	s.Error(err)
+
+	_, err = s.prUC.MergePR(s.ctx, "pr-meta")
+	s.Require().NoError(err)
+	_, err = s.prUC.UpdatePR(s.ctx, "pr-meta", usecases.PullRequestUpdate{Description: &description})
+	s.Error(err)
+}
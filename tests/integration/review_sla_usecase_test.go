@@ -0,0 +1,223 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-project/internal/application/usecases"
+	"go-project/internal/domain/entities"
+	"go-project/internal/domain/repositories"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestReviewSLAUseCaseIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite.Run(t, new(ReviewSLAUseCaseTestSuite))
+}
+
+type ReviewSLAUseCaseTestSuite struct {
+	IntegrationTestSuite
+}
+
+func (s *ReviewSLAUseCaseTestSuite) SetupTest() {
+	s.IntegrationTestSuite.SetupTest()
+
+	team := &entities.Team{
+		Name: "sla",
+		Members: []*entities.User{
+			{UserID: "author", Username: "author", IsActive: true},
+			{UserID: "lead", Username: "lead", IsActive: true, Role: entities.RoleLead},
+			{UserID: "rev1", Username: "rev1", IsActive: true},
+			{UserID: "rev2", Username: "rev2", IsActive: true},
+		},
+	}
+	s.Require().NoError(s.teamUC.CreateTeam(s.ctx, team))
+}
+
+func (s *ReviewSLAUseCaseTestSuite) setPolicy(requiredReviewers int, action entities.SLAAction, leadID string) {
+	policy := entities.DefaultTeamPolicy("sla")
+	policy.RequiredReviewers = requiredReviewers
+	policy.ReviewSLAHours = 24
+	policy.SLAAction = action
+	policy.TeamLeadID = leadID
+	_, err := s.teamUC.UpdatePolicy(s.ctx, policy)
+	s.Require().NoError(err)
+}
+
+func (s *ReviewSLAUseCaseTestSuite) TestProcessOverdueReviews_Reassigns() {
+	s.setPolicy(1, entities.SLAActionReassign, "")
+	_, _, _, err := s.userUC.SetUserActive(s.ctx, "lead", false)
+	s.Require().NoError(err)
+
+	pr, err := s.prUC.CreatePR(s.ctx, "author", "pr-sla-1", "Slow PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	overdueReviewer := pr.AssignedReviewers[0]
+
+	// До истечения SLA ничего не происходит
+	s.clock.now = s.clock.now.Add(23 * time.Hour)
+	escalations, err := s.slaUC.ProcessOverdueReviews(s.ctx)
+	s.NoError(err)
+	s.Empty(escalations)
+
+	s.clock.now = s.clock.now.Add(2 * time.Hour)
+	escalations, err = s.slaUC.ProcessOverdueReviews(s.ctx)
+	s.NoError(err)
+	s.Require().Len(escalations, 1)
+	s.Equal(overdueReviewer, escalations[0].ReviewerID)
+	s.Equal(entities.SLAActionReassign, escalations[0].Action)
+	s.NotEmpty(escalations[0].NewReviewerID)
+
+	updated, err := s.prRepo.GetByID(s.ctx, "pr-sla-1")
+	s.NoError(err)
+	s.Equal([]string{escalations[0].NewReviewerID}, updated.AssignedReviewers)
+
+	// Новое назначение отсчитывает SLA заново
+	escalations, err = s.slaUC.ProcessOverdueReviews(s.ctx)
+	s.NoError(err)
+	s.Empty(escalations)
+
+	history, err := s.slaUC.GetEscalations(s.ctx, "sla")
+	s.NoError(err)
+	s.Len(history, 1)
+
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, "pr-sla-1")
+	s.NoError(err)
+	s.Len(decisions, 2)
+	s.Equal(overdueReviewer, decisions[1].ReplacedUserID)
+}
+
+func (s *ReviewSLAUseCaseTestSuite) TestProcessOverdueReviews_EscalatesToLeadOnce() {
+	s.setPolicy(2, entities.SLAActionEscalate, "lead")
+	_, _, _, err := s.userUC.SetUserActive(s.ctx, "lead", false)
+	s.Require().NoError(err)
+
+	pr, err := s.prUC.CreatePR(s.ctx, "author", "pr-sla-2", "Slow PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"rev1", "rev2"}, pr.AssignedReviewers)
+
+	_, _, _, err = s.userUC.SetUserActive(s.ctx, "lead", true)
+	s.Require().NoError(err)
+
+	s.clock.now = s.clock.now.Add(25 * time.Hour)
+	escalations, err := s.slaUC.ProcessOverdueReviews(s.ctx)
+	s.NoError(err)
+	s.Require().Len(escalations, 2)
+	for _, escalation := range escalations {
+		s.Equal(entities.SLAActionEscalate, escalation.Action)
+		s.Equal("lead", escalation.NewReviewerID)
+	}
+
+	updated, err := s.prRepo.GetByID(s.ctx, "pr-sla-2")
+	s.NoError(err)
+	s.ElementsMatch([]string{"rev1", "rev2", "lead"}, updated.AssignedReviewers)
+
+	// Обработанные назначения повторно не эскалируются
+	escalations, err = s.slaUC.ProcessOverdueReviews(s.ctx)
+	s.NoError(err)
+	s.Empty(escalations)
+}
+
+func (s *ReviewSLAUseCaseTestSuite) TestProcessOverdueReviews_UpdateKeepsAssignedAt() {
+	s.setPolicy(2, entities.SLAActionReassign, "")
+
+	pr, err := s.prUC.CreatePR(s.ctx, "author", "pr-sla-3", "Slow PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	s.Require().Len(pr.AssignedReviewers, 2)
+	kept, replaced := pr.AssignedReviewers[0], pr.AssignedReviewers[1]
+
+	// Замена одного ревьювера не должна сбрасывать время назначения другого
+	s.clock.now = s.clock.now.Add(12 * time.Hour)
+	_, _, err = s.prUC.ReassignReviewer(s.ctx, "pr-sla-3", replaced, "")
+	s.Require().NoError(err)
+
+	s.clock.now = s.clock.now.Add(13 * time.Hour)
+	escalations, err := s.slaUC.ProcessOverdueReviews(s.ctx)
+	s.NoError(err)
+	s.Require().Len(escalations, 1)
+	s.Equal(kept, escalations[0].ReviewerID)
+}
+
+func (s *ReviewSLAUseCaseTestSuite) TestProcessOverdueReviews_SkipsSubmittedReviews() {
+	s.setPolicy(2, entities.SLAActionReassign, "")
+
+	pr, err := s.prUC.CreatePR(s.ctx, "author", "pr-sla-4", "Slow PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	reviewed, silent := pr.AssignedReviewers[0], pr.AssignedReviewers[1]
+
+	s.clock.now = s.clock.now.Add(time.Hour)
+	_, err = s.prUC.SubmitReview(s.ctx, "pr-sla-4", reviewed, entities.ReviewCommented, "looking")
+	s.Require().NoError(err)
+
+	s.clock.now = s.clock.now.Add(24 * time.Hour)
+	escalations, err := s.slaUC.ProcessOverdueReviews(s.ctx)
+	s.NoError(err)
+	s.Require().Len(escalations, 1)
+	s.Equal(silent, escalations[0].ReviewerID)
+}
+
+// Отказывает в чтении одного PR, имитируя сбой при обработке его ревью
+type failingPRRepo struct {
+	repositories.PullRequestRepository
+	failID string
+}
+
+func (r *failingPRRepo) GetByID(ctx context.Context, id string) (*entities.PullRequest, error) {
+	if id == r.failID {
+		return nil, errors.New("connection reset")
+	}
+	return r.PullRequestRepository.GetByID(ctx, id)
+}
+
+func (s *ReviewSLAUseCaseTestSuite) TestProcessOverdueReviews_ContinuesAfterFailure() {
+	s.setPolicy(1, entities.SLAActionReassign, "")
+
+	_, err := s.prUC.CreatePR(s.ctx, "author", "pr-sla-broken", "Slow PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	pr, err := s.prUC.CreatePR(s.ctx, "author", "pr-sla-5", "Slow PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	prRepo := &failingPRRepo{PullRequestRepository: s.prRepo, failID: "pr-sla-broken"}
+	slaUC := usecases.NewReviewSLAUseCase(prRepo, s.teamRepo, s.prUC, s.db)
+
+	s.clock.now = s.clock.now.Add(25 * time.Hour)
+	escalations, err := slaUC.ProcessOverdueReviews(s.ctx)
+	s.NoError(err)
+	s.Require().Len(escalations, 1)
+	s.Equal("pr-sla-5", escalations[0].PullRequestID)
+	s.Equal(pr.AssignedReviewers[0], escalations[0].ReviewerID)
+
+	// Сбойный PR остается просроченным и будет обработан в следующий запуск
+	escalations, err = s.slaUC.ProcessOverdueReviews(s.ctx)
+	s.NoError(err)
+	s.Require().Len(escalations, 1)
+	s.Equal("pr-sla-broken", escalations[0].PullRequestID)
+}
+
+func (s *ReviewSLAUseCaseTestSuite) TestUpdatePolicy_EscalateRequiresTeamLead() {
+	policy := entities.DefaultTeamPolicy("sla")
+	policy.ReviewSLAHours = 24
+	policy.SLAAction = entities.SLAActionEscalate
+
+	_, err := s.teamUC.UpdatePolicy(s.ctx, policy)
+	s.Error(err)
+
+	policy.TeamLeadID = "stranger"
+	_, err = s.teamUC.UpdatePolicy(s.ctx, policy)
+	s.Error(err)
+
+	// Тимлидом может быть только участник с ролью lead
+	policy.TeamLeadID = "rev1"
+	_, err = s.teamUC.UpdatePolicy(s.ctx, policy)
+	s.Error(err)
+	s.Contains(err.Error(), "role lead")
+
+	policy.TeamLeadID = "lead"
+	_, err = s.teamUC.UpdatePolicy(s.ctx, policy)
+	s.NoError(err)
+}
@@ -1,9 +1,13 @@
 package integration
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
+	"go-project/internal/application/usecases"
 	"go-project/internal/domain/entities"
+	"go-project/internal/domain/repositories"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -88,3 +92,479 @@ func (s *TeamUseCaseTestSuite) TestGetTeam_NotFound() {
 	s.Nil(team)
 	s.Contains(err.Error(), "resource not found")
 }
+
+func (s *TeamUseCaseTestSuite) TestCreateTeam_StoresRoles() {
+	team := &entities.Team{
+		Name: "Roles Team",
+		Members: []*entities.User{
+			{UserID: "user1", Username: "lead", IsActive: true, Role: entities.RoleLead},
+			{UserID: "user2", Username: "member", IsActive: true},
+		},
+	}
+	s.Require().NoError(s.teamUC.CreateTeam(s.ctx, team))
+
+	stored, err := s.teamUC.GetTeam(s.ctx, "Roles Team")
+	s.NoError(err)
+	roles := map[string]entities.TeamRole{}
+	for _, member := range stored.Members {
+		roles[member.UserID] = member.Role
+	}
+	s.Equal(map[string]entities.TeamRole{"user1": entities.RoleLead, "user2": entities.RoleMember}, roles)
+
+	invalid := &entities.Team{
+		Name:    "Invalid Roles Team",
+		Members: []*entities.User{{UserID: "user3", Username: "user3", IsActive: true, Role: "intern"}},
+	}
+	s.Error(s.teamUC.CreateTeam(s.ctx, invalid))
+}
+
+func (s *TeamUseCaseTestSuite) TestGetPolicy_Defaults() {
+	team := &entities.Team{
+		Name: "Policy Team",
+		Members: []*entities.User{
+			{UserID: "user4", Username: "bob", IsActive: true},
+		},
+	}
+	s.teamUC.CreateTeam(s.ctx, team)
+
+	policy, err := s.teamUC.GetPolicy(s.ctx, "Policy Team")
+
+	s.NoError(err)
+	s.Equal(entities.DefaultRequiredReviewers, policy.RequiredReviewers)
+	s.Equal(entities.DefaultMinApprovals, policy.MinApprovals)
+	s.Empty(policy.Strategy)
+}
+
+func (s *TeamUseCaseTestSuite) TestUpdatePolicy_Success() {
+	team := &entities.Team{
+		Name: "Policy Team",
+		Members: []*entities.User{
+			{UserID: "user4", Username: "bob", IsActive: true},
+		},
+	}
+	s.teamUC.CreateTeam(s.ctx, team)
+
+	_, err := s.teamUC.UpdatePolicy(s.ctx, &entities.TeamPolicy{
+		TeamName:          "Policy Team",
+		RequiredReviewers: 3,
+		MinApprovals:      1,
+		Strategy:          entities.StrategyRoundRobin,
+	})
+	s.NoError(err)
+
+	policy, err := s.teamUC.GetPolicy(s.ctx, "Policy Team")
+	s.NoError(err)
+	s.Equal(3, policy.RequiredReviewers)
+	s.Equal(1, policy.MinApprovals)
+	s.Equal(entities.StrategyRoundRobin, policy.Strategy)
+}
+
+func (s *TeamUseCaseTestSuite) TestUpdatePolicy_ApprovalsAboveReviewers() {
+	team := &entities.Team{
+		Name: "Policy Team",
+		Members: []*entities.User{
+			{UserID: "user4", Username: "bob", IsActive: true},
+		},
+	}
+	s.teamUC.CreateTeam(s.ctx, team)
+
+	policy, err := s.teamUC.UpdatePolicy(s.ctx, &entities.TeamPolicy{
+		TeamName:          "Policy Team",
+		RequiredReviewers: 1,
+		MinApprovals:      2,
+	})
+
+	s.Error(err)
+	s.Nil(policy)
+}
+
+func (s *TeamUseCaseTestSuite) createReviewTeam(name string, size int) []string {
+	team := &entities.Team{Name: name}
+	var userIDs []string
+	for i := 0; i < size; i++ {
+		userID := fmt.Sprintf("%s-u%03d", name, i)
+		team.Members = append(team.Members, &entities.User{UserID: userID, Username: userID, IsActive: true})
+		userIDs = append(userIDs, userID)
+	}
+	s.Require().NoError(s.teamUC.CreateTeam(s.ctx, team))
+	return userIDs
+}
+
+func (s *TeamUseCaseTestSuite) TestDeactivateMembers_ReassignsOpenReviews() {
+	users := s.createReviewTeam("ops", 5)
+	author := users[0]
+
+	merged, err := s.prUC.CreatePR(s.ctx, author, "pr-merged", "Merged PR", usecases.CreatePROptions{})
+	s.NoError(err)
+	_, err = s.prUC.MergePR(s.ctx, merged.ID)
+	s.NoError(err)
+
+	// После мержа загрузка снова нулевая, поэтому открытый PR достается тем же ревьюверам
+	open, err := s.prUC.CreatePR(s.ctx, author, "pr-open", "Open PR", usecases.CreatePROptions{})
+	s.NoError(err)
+	s.ElementsMatch(merged.AssignedReviewers, open.AssignedReviewers)
+
+	leaving := open.AssignedReviewers
+	summary, err := s.teamUC.DeactivateMembers(s.ctx, "ops", leaving)
+
+	s.NoError(err)
+	s.Len(summary.Reassigned, 2)
+	s.Empty(summary.Unassignable)
+	s.Equal([]string{"pr-merged"}, summary.Untouched)
+
+	pr, _ := s.prRepo.GetByID(s.ctx, "pr-open")
+	s.Len(pr.AssignedReviewers, 2)
+	for _, userID := range leaving {
+		s.NotContains(pr.AssignedReviewers, userID)
+		user, _ := s.userRepo.GetByID(s.ctx, userID)
+		s.False(user.IsActive)
+	}
+
+	stored, _ := s.prRepo.GetByID(s.ctx, "pr-merged")
+	s.ElementsMatch(merged.AssignedReviewers, stored.AssignedReviewers)
+}
+
+func (s *TeamUseCaseTestSuite) TestDeactivateMembers_ReportsUnassignable() {
+	users := s.createReviewTeam("tiny", 3)
+
+	pr, err := s.prUC.CreatePR(s.ctx, users[0], "pr-tiny", "Tiny PR", usecases.CreatePROptions{})
+	s.NoError(err)
+
+	summary, err := s.teamUC.DeactivateMembers(s.ctx, "tiny", []string{users[1]})
+
+	s.NoError(err)
+	s.Empty(summary.Reassigned)
+	s.Equal([]usecases.ReviewerReplacement{{PullRequestID: "pr-tiny", OldReviewerID: users[1]}}, summary.Unassignable)
+
+	stored, _ := s.prRepo.GetByID(s.ctx, "pr-tiny")
+	s.ElementsMatch(pr.AssignedReviewers, stored.AssignedReviewers)
+}
+
+func (s *TeamUseCaseTestSuite) TestDeactivateMembers_UnknownMemberChangesNothing() {
+	users := s.createReviewTeam("infra", 3)
+
+	summary, err := s.teamUC.DeactivateMembers(s.ctx, "infra", []string{users[1], "stranger"})
+
+	s.Error(err)
+	s.Nil(summary)
+	user, _ := s.userRepo.GetByID(s.ctx, users[1])
+	s.True(user.IsActive)
+}
+
+// Считает обращения к репозиториям, чтобы проверить, что массовая замена не читает данные по каждому PR
+type countingTeamRepo struct {
+	repositories.TeamRepository
+	calls map[string]int
+}
+
+func (r *countingTeamRepo) GetByName(ctx context.Context, name string) (*entities.Team, error) {
+	r.calls["GetByName"]++
+	return r.TeamRepository.GetByName(ctx, name)
+}
+
+func (r *countingTeamRepo) GetPolicy(ctx context.Context, teamName string) (*entities.TeamPolicy, error) {
+	r.calls["GetPolicy"]++
+	return r.TeamRepository.GetPolicy(ctx, teamName)
+}
+
+type countingPRRepo struct {
+	repositories.PullRequestRepository
+	calls map[string]int
+}
+
+func (r *countingPRRepo) GetByID(ctx context.Context, id string) (*entities.PullRequest, error) {
+	r.calls["GetByID"]++
+	return r.PullRequestRepository.GetByID(ctx, id)
+}
+
+func (r *countingPRRepo) GetByReviewers(ctx context.Context, reviewerIDs []string) ([]*entities.PullRequest, error) {
+	r.calls["GetByReviewers"]++
+	return r.PullRequestRepository.GetByReviewers(ctx, reviewerIDs)
+}
+
+func (r *countingPRRepo) Update(ctx context.Context, pr *entities.PullRequest) error {
+	r.calls["Update"]++
+	return r.PullRequestRepository.Update(ctx, pr)
+}
+
+func (s *TeamUseCaseTestSuite) TestDeactivateMembers_LargeTeam() {
+	users := s.createReviewTeam("platform", 400)
+	_, err := s.teamUC.UpdatePolicy(s.ctx, &entities.TeamPolicy{TeamName: "platform", RequiredReviewers: 2})
+	s.NoError(err)
+
+	for i := 0; i < 200; i++ {
+		_, err := s.prUC.CreatePR(s.ctx, users[i], fmt.Sprintf("pr-%03d", i), "PR", usecases.CreatePROptions{})
+		s.Require().NoError(err)
+	}
+
+	teamRepo := &countingTeamRepo{TeamRepository: s.teamRepo, calls: map[string]int{}}
+	prRepo := &countingPRRepo{PullRequestRepository: s.prRepo, calls: map[string]int{}}
+	prUC := usecases.NewPullRequestUseCase(prRepo, teamRepo, s.userRepo, s.ruleRepo, s.db,
+		entities.StrategyLeastOpenReviews, s.clock)
+	teamUC := usecases.NewTeamUseCase(teamRepo, s.userRepo, prUC, s.db)
+
+	summary, err := teamUC.DeactivateMembers(s.ctx, "platform", users[:200])
+
+	s.NoError(err)
+	s.NotEmpty(summary.Reassigned)
+	s.Empty(summary.Unassignable)
+
+	// PR читаются одним запросом, команда и политика - один раз на всю операцию,
+	// каждый затронутый PR записывается один раз независимо от числа замен в нем
+	updated := make(map[string]bool)
+	for _, replacement := range summary.Reassigned {
+		updated[replacement.PullRequestID] = true
+	}
+	s.Equal(1, prRepo.calls["GetByReviewers"])
+	s.Zero(prRepo.calls["GetByID"])
+	s.Equal(len(updated), prRepo.calls["Update"])
+	s.Equal(1, teamRepo.calls["GetByName"])
+	s.Equal(1, teamRepo.calls["GetPolicy"])
+}
+
+// Ревьюверы users[1] и users[2] получают по два PR, пока users[3] неактивен
+func (s *TeamUseCaseTestSuite) createUnbalancedTeam(name string) []string {
+	users := s.createReviewTeam(name, 4)
+	_, err := s.teamUC.UpdatePolicy(s.ctx, &entities.TeamPolicy{TeamName: name, RequiredReviewers: 1})
+	s.Require().NoError(err)
+
+	_, _, _, err = s.userUC.SetUserActive(s.ctx, users[3], false)
+	s.Require().NoError(err)
+	for i := 1; i <= 4; i++ {
+		_, err := s.prUC.CreatePR(s.ctx, users[0], fmt.Sprintf("pr-%s-%d", name, i), "PR", usecases.CreatePROptions{})
+		s.Require().NoError(err)
+	}
+	_, _, _, err = s.userUC.SetUserActive(s.ctx, users[3], true)
+	s.Require().NoError(err)
+
+	return users
+}
+
+func (s *TeamUseCaseTestSuite) TestRebalanceTeam_MovesToUnderloaded() {
+	users := s.createUnbalancedTeam("rb")
+
+	moves, err := s.teamUC.RebalanceTeam(s.ctx, "rb")
+
+	s.NoError(err)
+	s.Require().Len(moves, 1)
+	s.Equal(users[2], moves[0].FromUserID)
+	s.Equal(users[3], moves[0].ToUserID)
+
+	pr, _ := s.prRepo.GetByID(s.ctx, moves[0].PullRequestID)
+	s.Equal([]string{users[3]}, pr.AssignedReviewers)
+
+	decisions, err := s.prUC.GetAssignmentLog(s.ctx, moves[0].PullRequestID)
+	s.NoError(err)
+	s.Equal(entities.ActionRebalance, decisions[len(decisions)-1].Action)
+	s.Equal(users[2], decisions[len(decisions)-1].ReplacedUserID)
+
+	// Нагрузка уже выровнена
+	moves, err = s.teamUC.RebalanceTeam(s.ctx, "rb")
+	s.NoError(err)
+	s.Empty(moves)
+}
+
+func (s *TeamUseCaseTestSuite) TestRebalanceTeam_SkipsPinnedAndStartedReviews() {
+	users := s.createUnbalancedTeam("rbs")
+
+	for _, prID := range []string{"pr-rbs-1", "pr-rbs-3"} {
+		_, err := s.prUC.SubmitReview(s.ctx, prID, users[1], entities.ReviewCommented, "")
+		s.Require().NoError(err)
+	}
+	for _, prID := range []string{"pr-rbs-2", "pr-rbs-4"} {
+		_, err := s.prUC.PinReviewer(s.ctx, prID, users[2], true)
+		s.Require().NoError(err)
+	}
+
+	moves, err := s.teamUC.RebalanceTeam(s.ctx, "rbs")
+
+	s.NoError(err)
+	s.Empty(moves)
+}
+
+func (s *TeamUseCaseTestSuite) TestRemoveMember_ReassignsOpenReviews() {
+	users := s.createReviewTeam("payments", 4)
+
+	pr, err := s.prUC.CreatePR(s.ctx, users[0], "pr-payments", "Payments PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	leaving := pr.AssignedReviewers[0]
+
+	team, summary, err := s.teamUC.RemoveMember(s.ctx, "payments", leaving)
+	s.Require().NoError(err)
+	s.Len(team.Members, 3)
+	s.Require().Len(summary.Reassigned, 1)
+	s.Equal(leaving, summary.Reassigned[0].OldReviewerID)
+
+	stored, _ := s.prRepo.GetByID(s.ctx, "pr-payments")
+	s.NotContains(stored.AssignedReviewers, leaving)
+	s.Len(stored.AssignedReviewers, 2)
+
+	// Пользователь остается активным, меняется только состав команды
+	user, _ := s.userRepo.GetByID(s.ctx, leaving)
+	s.True(user.IsActive)
+
+	_, _, err = s.teamUC.RemoveMember(s.ctx, "payments", leaving)
+	s.Error(err)
+}
+
+func (s *TeamUseCaseTestSuite) TestUpdateTeam_DeactivationReassignsOpenReviews() {
+	users := s.createReviewTeam("search", 4)
+
+	pr, err := s.prUC.CreatePR(s.ctx, users[0], "pr-search", "Search PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	deactivated := pr.AssignedReviewers[0]
+
+	// Деактивация через состав команды переназначает ревью так же, как SetUserActive
+	team := &entities.Team{Name: "search"}
+	for _, userID := range users {
+		team.Members = append(team.Members,
+			&entities.User{UserID: userID, Username: userID + "-renamed", IsActive: userID != deactivated})
+	}
+	team.Members = append(team.Members, &entities.User{UserID: "search-new", Username: "search-new", IsActive: true})
+
+	updated, summary, err := s.teamUC.UpdateTeam(s.ctx, team)
+	s.Require().NoError(err)
+	s.Len(updated.Members, 5)
+	s.Require().Len(summary.Reassigned, 1)
+	s.Equal("pr-search", summary.Reassigned[0].PullRequestID)
+	s.Equal(deactivated, summary.Reassigned[0].OldReviewerID)
+
+	user, err := s.userRepo.GetByID(s.ctx, deactivated)
+	s.NoError(err)
+	s.False(user.IsActive)
+	s.Equal(deactivated+"-renamed", user.Username)
+
+	stored, err := s.prRepo.GetByID(s.ctx, "pr-search")
+	s.NoError(err)
+	s.NotContains(stored.AssignedReviewers, deactivated)
+	s.Contains(stored.AssignedReviewers, summary.Reassigned[0].NewReviewerID)
+	s.False(stored.NeedsReviewer)
+
+	// Создание команды с существующим пользователем применяет переданную активность
+	created := &entities.Team{Name: "search-guild", Members: []*entities.User{
+		{UserID: deactivated, Username: deactivated, IsActive: true},
+	}}
+	s.Require().NoError(s.teamUC.CreateTeam(s.ctx, created))
+
+	user, err = s.userRepo.GetByID(s.ctx, deactivated)
+	s.NoError(err)
+	s.True(user.IsActive)
+}
+
+func (s *TeamUseCaseTestSuite) TestDeleteTeam_RefusedWithOpenPRs() {
+	users := s.createReviewTeam("legacy", 3)
+
+	_, err := s.prUC.CreatePR(s.ctx, users[0], "pr-legacy", "Legacy PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	_, err = s.teamUC.DeleteTeam(s.ctx, "legacy", false)
+	s.Error(err)
+	_, err = s.teamUC.GetTeam(s.ctx, "legacy")
+	s.NoError(err)
+
+	openPRs, err := s.teamUC.DeleteTeam(s.ctx, "legacy", true)
+	s.NoError(err)
+	s.Equal([]string{"pr-legacy"}, openPRs)
+	_, err = s.teamUC.GetTeam(s.ctx, "legacy")
+	s.Error(err)
+}
+
+func (s *TeamUseCaseTestSuite) TestDeleteTeam_ReviewerOfTeamlessPRCanBeDeactivated() {
+	users := s.createReviewTeam("orphans", 3)
+	pr, err := s.prUC.CreatePR(s.ctx, users[0], "pr-orphan", "Orphan PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	// У автора нет другой команды: PR остается без команды
+	_, err = s.teamUC.DeleteTeam(s.ctx, "orphans", true)
+	s.Require().NoError(err)
+
+	// Замены нет, но деактивация не падает: PR попадает в unassignable и помечается needs_reviewer
+	reviewer := pr.AssignedReviewers[0]
+	_, _, summary, err := s.userUC.SetUserActive(s.ctx, reviewer, false)
+	s.Require().NoError(err)
+	s.Equal([]usecases.ReviewerReplacement{{PullRequestID: "pr-orphan", OldReviewerID: reviewer}}, summary.Unassignable)
+
+	stored, err := s.prUC.GetPR(s.ctx, "pr-orphan")
+	s.Require().NoError(err)
+	s.True(stored.NeedsReviewer)
+
+	_, _, err = s.prUC.ReassignReviewer(s.ctx, "pr-orphan", pr.AssignedReviewers[1], "")
+	s.Error(err)
+	s.Contains(err.Error(), "NO_CANDIDATE")
+}
+
+func (s *TeamUseCaseTestSuite) TestDeleteTeam_TeamlessPRFollowsAuthorsNewTeam() {
+	users := s.createReviewTeam("sunset", 3)
+	pr, err := s.prUC.CreatePR(s.ctx, users[0], "pr-sunset", "Sunset PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	_, err = s.teamUC.DeleteTeam(s.ctx, "sunset", true)
+	s.Require().NoError(err)
+	stored, err := s.prUC.GetPR(s.ctx, "pr-sunset")
+	s.Require().NoError(err)
+	s.Empty(stored.TeamName)
+
+	// PR без команды относится к текущей основной команде автора
+	newcomers := s.createReviewTeam("sunrise", 2)
+	_, err = s.teamUC.AddMember(s.ctx, "sunrise", users[0], "")
+	s.Require().NoError(err)
+
+	_, newReviewer, err := s.prUC.ReassignReviewer(s.ctx, "pr-sunset", pr.AssignedReviewers[0], "")
+	s.Require().NoError(err)
+	s.Contains(newcomers, newReviewer)
+}
+
+func (s *TeamUseCaseTestSuite) TestRenameTeam_KeepsMembersAndPolicy() {
+	s.createReviewTeam("web", 3)
+	s.createReviewTeam("mobile", 2)
+	_, err := s.teamUC.UpdatePolicy(s.ctx, &entities.TeamPolicy{TeamName: "web", RequiredReviewers: 1, MinApprovals: 1})
+	s.Require().NoError(err)
+
+	_, err = s.teamUC.RenameTeam(s.ctx, "web", "mobile")
+	s.Error(err)
+
+	team, err := s.teamUC.RenameTeam(s.ctx, "web", "frontend")
+	s.Require().NoError(err)
+	s.Equal("frontend", team.Name)
+	s.Len(team.Members, 3)
+
+	policy, err := s.teamUC.GetPolicy(s.ctx, "frontend")
+	s.NoError(err)
+	s.Equal(1, policy.MinApprovals)
+
+	_, err = s.teamUC.GetTeam(s.ctx, "web")
+	s.Error(err)
+}
+
+func (s *TeamUseCaseTestSuite) TestListTeams_PagesAndCounts() {
+	users := s.createReviewTeam("search-a", 3)
+	s.createReviewTeam("search-b", 2)
+	s.createReviewTeam("search-c", 1)
+	s.createReviewTeam("other", 1)
+
+	_, err := s.teamUC.UpdatePolicy(s.ctx, &entities.TeamPolicy{TeamName: "search-a", RequiredReviewers: 2})
+	s.Require().NoError(err)
+	_, _, _, err = s.userUC.SetUserActive(s.ctx, users[2], false)
+	s.Require().NoError(err)
+	_, err = s.prUC.CreatePR(s.ctx, users[0], "pr-search", "Search PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	teams, cursor, err := s.teamUC.ListTeams(s.ctx, "SEARCH", "", 2)
+	s.Require().NoError(err)
+	s.Require().Len(teams, 2)
+	s.NotEmpty(cursor)
+	s.Equal("search-a", teams[0].TeamName)
+	s.Equal(3, teams[0].MemberCount)
+	s.Equal(2, teams[0].ActiveCount)
+	s.Equal(1, teams[0].BusyCount)
+	s.Equal("search-b", teams[1].TeamName)
+
+	teams, cursor, err = s.teamUC.ListTeams(s.ctx, "SEARCH", cursor, 2)
+	s.Require().NoError(err)
+	s.Require().Len(teams, 1)
+	s.Equal("search-c", teams[0].TeamName)
+	s.Empty(cursor)
+
+	_, _, err = s.teamUC.ListTeams(s.ctx, "", "not a cursor", 0)
+	s.Error(err)
+}
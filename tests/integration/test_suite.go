@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"go-project/config"
 	"go-project/internal/application/usecases"
+	"go-project/internal/domain/entities"
 	"go-project/internal/domain/repositories"
 	"go-project/internal/infrastructure/postgres_database/migrations"
 
@@ -25,10 +27,24 @@ type IntegrationTestSuite struct {
 	teamRepo repositories.TeamRepository
 	userRepo repositories.UserRepository
 	prRepo   repositories.PullRequestRepository
+	ruleRepo repositories.OwnershipRuleRepository
 
-	teamUC *usecases.TeamUseCase
-	userUC *usecases.UserUseCase
-	prUC   *usecases.PullRequestUseCase
+	teamUC      *usecases.TeamUseCase
+	userUC      *usecases.UserUseCase
+	prUC        *usecases.PullRequestUseCase
+	ownershipUC *usecases.OwnershipUseCase
+	slaUC       *usecases.ReviewSLAUseCase
+
+	clock *testClock
+}
+
+// Часы, которые тест может перевести
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
 }
 
 func (s *IntegrationTestSuite) SetupSuite() {
@@ -67,12 +83,17 @@ func (s *IntegrationTestSuite) initializeRepositories() {
 	s.teamRepo = postgresRepos.NewTeamRepository(s.db)
 	s.userRepo = postgresRepos.NewUserRepository(s.db)
 	s.prRepo = postgresRepos.NewPullRequestRepository(s.db)
+	s.ruleRepo = postgresRepos.NewOwnershipRuleRepository(s.db)
 }
 
 func (s *IntegrationTestSuite) initializeUseCases() {
-	s.teamUC = usecases.NewTeamUseCase(s.teamRepo, s.userRepo)
-	s.userUC = usecases.NewUserUseCase(s.userRepo, s.teamRepo)
-	s.prUC = usecases.NewPullRequestUseCase(s.prRepo, s.teamRepo, s.userRepo)
+	s.clock = &testClock{now: time.Now()}
+	s.prUC = usecases.NewPullRequestUseCase(s.prRepo, s.teamRepo, s.userRepo, s.ruleRepo, s.db,
+		entities.StrategyLeastOpenReviews, s.clock)
+	s.teamUC = usecases.NewTeamUseCase(s.teamRepo, s.userRepo, s.prUC, s.db)
+	s.userUC = usecases.NewUserUseCase(s.userRepo, s.teamRepo, s.prUC, s.db)
+	s.ownershipUC = usecases.NewOwnershipUseCase(s.ruleRepo, s.teamRepo, s.userRepo)
+	s.slaUC = usecases.NewReviewSLAUseCase(s.prRepo, s.teamRepo, s.prUC, s.db)
 }
 
 func (s *IntegrationTestSuite) TearDownSuite() {
@@ -82,7 +103,9 @@ func (s *IntegrationTestSuite) TearDownSuite() {
 }
 
 func (s *IntegrationTestSuite) SetupTest() {
-	tables := []string{"pull_request_reviewers", "pull_requests", "team_members", "teams", "users"}
+	s.clock.now = time.Now()
+
+	tables := []string{"pull_request_reviewers", "pull_requests", "team_members", "teams", "users", "ownership_rules", "user_skills", "pull_request_labels", "user_out_of_office", "assignment_decisions", "review_escalations", "pull_request_reviews"}
 	for _, table := range tables {
 		_, err := s.db.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE", table))
 		if err != nil {
@@ -1,8 +1,11 @@
 package integration
 
 import (
+	"go-project/internal/application/usecases"
 	"go-project/internal/domain/entities"
+	"go-project/internal/domain/errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -40,7 +43,7 @@ func (s *UserUseCaseTestSuite) setupTestData() {
 }
 
 func (s *UserUseCaseTestSuite) TestSetUserActive_Success() {
-	user, teamName, err := s.userUC.SetUserActive(s.ctx, "test_user", false)
+	user, teamName, _, err := s.userUC.SetUserActive(s.ctx, "test_user", false)
 
 	s.NoError(err)
 	s.NotNil(user)
@@ -52,10 +55,200 @@ func (s *UserUseCaseTestSuite) TestSetUserActive_Success() {
 }
 
 func (s *UserUseCaseTestSuite) TestSetUserActive_UserNotFound() {
-	user, teamName, err := s.userUC.SetUserActive(s.ctx, "non_existent_user", true)
+	user, teamName, _, err := s.userUC.SetUserActive(s.ctx, "non_existent_user", true)
 
 	s.Error(err)
 	s.Nil(user)
 	s.Empty(teamName)
 	s.Contains(err.Error(), "user not found")
 }
+
+func (s *UserUseCaseTestSuite) TestSetMaxOpenReviews_Success() {
+	limit := 5
+	user, err := s.userUC.SetMaxOpenReviews(s.ctx, "test_user", &limit)
+
+	s.NoError(err)
+	s.Equal(5, user.ReviewCapacity)
+
+	user, err = s.userUC.SetMaxOpenReviews(s.ctx, "test_user", nil)
+
+	s.NoError(err)
+	s.Nil(user.MaxOpenReviews)
+	s.Equal(entities.DefaultMaxOpenReviews, user.ReviewCapacity)
+}
+
+func (s *UserUseCaseTestSuite) TestSetMaxOpenReviews_NotPositive() {
+	limit := 0
+	user, err := s.userUC.SetMaxOpenReviews(s.ctx, "test_user", &limit)
+
+	s.Error(err)
+	s.Nil(user)
+	s.Contains(err.Error(), "must be positive")
+}
+
+func (s *UserUseCaseTestSuite) TestSetSkills_Normalizes() {
+	user, err := s.userUC.SetSkills(s.ctx, "test_user", []string{" Go ", "postgres", "go"})
+
+	s.NoError(err)
+	s.Equal([]string{"go", "postgres"}, user.Skills)
+
+	user, err = s.userUC.SetSkills(s.ctx, "test_user", nil)
+
+	s.NoError(err)
+	s.Empty(user.Skills)
+}
+
+func (s *UserUseCaseTestSuite) TestSetSkills_UserNotFound() {
+	user, err := s.userUC.SetSkills(s.ctx, "non_existent_user", []string{"go"})
+
+	s.Error(err)
+	s.Nil(user)
+	s.Contains(err.Error(), "user not found")
+}
+
+func (s *UserUseCaseTestSuite) TestOutOfOffice_AddListDelete() {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	period, err := s.userUC.AddOutOfOffice(s.ctx, "test_user", start, start.AddDate(0, 0, 14), "vacation")
+
+	s.NoError(err)
+	s.NotZero(period.ID)
+
+	periods, err := s.userUC.GetOutOfOffice(s.ctx, "test_user")
+	s.NoError(err)
+	s.Len(periods, 1)
+	s.Equal("vacation", periods[0].Reason)
+	s.True(periods[0].StartsAt.Equal(start))
+
+	s.NoError(s.userUC.DeleteOutOfOffice(s.ctx, "test_user", period.ID))
+
+	periods, err = s.userUC.GetOutOfOffice(s.ctx, "test_user")
+	s.NoError(err)
+	s.Empty(periods)
+
+	err = s.userUC.DeleteOutOfOffice(s.ctx, "test_user", period.ID)
+	s.Error(err)
+	s.Contains(err.Error(), "not found")
+}
+
+func (s *UserUseCaseTestSuite) TestOutOfOffice_EndBeforeStart() {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	period, err := s.userUC.AddOutOfOffice(s.ctx, "test_user", start, start.Add(-time.Hour), "")
+
+	s.Error(err)
+	s.Nil(period)
+	s.Contains(err.Error(), "ends_at must be after starts_at")
+}
+
+func (s *UserUseCaseTestSuite) TestSetWorkSchedule_Validation() {
+	schedule := &entities.WorkSchedule{Timezone: "Mars/Olympus", Start: "09:00", End: "18:00"}
+	user, err := s.userUC.SetWorkSchedule(s.ctx, "test_user", schedule)
+
+	s.Error(err)
+	s.Nil(user)
+	s.Contains(err.Error(), "unknown timezone")
+
+	schedule = &entities.WorkSchedule{Timezone: "Europe/Moscow", Start: "22:00", End: "06:00"}
+	user, err = s.userUC.SetWorkSchedule(s.ctx, "test_user", schedule)
+
+	s.NoError(err)
+	s.Equal(schedule, user.Schedule)
+
+	user, err = s.userUC.SetWorkSchedule(s.ctx, "test_user", nil)
+
+	s.NoError(err)
+	s.Nil(user.Schedule)
+}
+
+func (s *UserUseCaseTestSuite) TestMultipleTeams_PrimaryTeamAndReviews() {
+	team := &entities.Team{
+		Name: "Platform",
+		Members: []*entities.User{
+			{UserID: "test_user", Username: "test_user", IsActive: true, Role: entities.RoleSenior},
+			{UserID: "platform_peer", Username: "platform_peer", IsActive: true},
+		},
+	}
+	s.Require().NoError(s.teamUC.CreateTeam(s.ctx, team))
+
+	// Участие в первой команде сохраняется, она остается основной
+	memberships, err := s.userUC.GetTeams(s.ctx, "test_user")
+	s.Require().NoError(err)
+	s.Require().Len(memberships, 2)
+	s.Equal("Test Team", memberships[0].TeamName)
+	s.True(memberships[0].IsPrimary)
+	s.Equal("Platform", memberships[1].TeamName)
+	s.Equal(entities.RoleSenior, memberships[1].Role)
+
+	// Ревьювером пользователь назначается и во второй команде
+	pr, err := s.prUC.CreatePR(s.ctx, "platform_peer", "pr-multi-1", "Platform PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+	s.Equal([]string{"test_user"}, pr.AssignedReviewers)
+
+	memberships, err = s.userUC.SetPrimaryTeam(s.ctx, "test_user", "Platform")
+	s.Require().NoError(err)
+	s.Equal("Platform", memberships[0].TeamName)
+	s.True(memberships[0].IsPrimary)
+	s.False(memberships[1].IsPrimary)
+
+	_, teamName, _, err := s.userUC.SetUserActive(s.ctx, "test_user", true)
+	s.NoError(err)
+	s.Equal("Platform", teamName)
+
+	_, err = s.userUC.SetPrimaryTeam(s.ctx, "test_user", "Unknown Team")
+	s.Error(err)
+}
+
+func (s *UserUseCaseTestSuite) TestCreateAndUpdateUser_UsernameTaken() {
+	user, err := s.userUC.CreateUser(s.ctx, &entities.User{UserID: "loner", Username: "loner", IsActive: true})
+	s.Require().NoError(err)
+	s.Empty(user.TeamName)
+	s.Equal(entities.DefaultMaxOpenReviews, user.ReviewCapacity)
+
+	_, err = s.userUC.CreateUser(s.ctx, &entities.User{UserID: "loner", Username: "other", IsActive: true})
+	s.Require().Error(err)
+	s.Equal(errors.ErrUserExists, err.(errors.DomainError).Code)
+
+	_, err = s.userUC.UpdateUser(s.ctx, "loner", "test_user")
+	s.Require().Error(err)
+	s.Equal(errors.ErrUsernameTaken, err.(errors.DomainError).Code)
+
+	user, err = s.userUC.UpdateUser(s.ctx, "loner", "renamed")
+	s.NoError(err)
+	s.Equal("renamed", user.Username)
+
+	user, err = s.userUC.GetUser(s.ctx, "test_user")
+	s.NoError(err)
+	s.Equal("Test Team", user.TeamName)
+}
+
+func (s *UserUseCaseTestSuite) TestListUsers_FiltersAndPages() {
+	team := &entities.Team{
+		Name: "Directory",
+		Members: []*entities.User{
+			{UserID: "dir-1", Username: "Dir Alice", IsActive: true},
+			{UserID: "dir-2", Username: "Dir Bob", IsActive: true},
+			{UserID: "dir-3", Username: "Dir Carol", IsActive: false},
+		},
+	}
+	s.Require().NoError(s.teamUC.CreateTeam(s.ctx, team))
+	_, err := s.prUC.CreatePR(s.ctx, "dir-1", "pr-dir", "Directory PR", usecases.CreatePROptions{})
+	s.Require().NoError(err)
+
+	users, cursor, err := s.userUC.ListUsers(s.ctx, entities.UserListFilter{TeamName: "Directory", Limit: 2}, "")
+	s.Require().NoError(err)
+	s.Require().Len(users, 2)
+	s.Equal("dir-1", users[0].UserID)
+	s.NotEmpty(cursor)
+
+	users, cursor, err = s.userUC.ListUsers(s.ctx, entities.UserListFilter{TeamName: "Directory", Limit: 2}, cursor)
+	s.Require().NoError(err)
+	s.Require().Len(users, 1)
+	s.Equal("dir-3", users[0].UserID)
+	s.Empty(cursor)
+
+	active, busy := true, true
+	users, _, err = s.userUC.ListUsers(s.ctx, entities.UserListFilter{IsActive: &active, Busy: &busy, Search: "dir"}, "")
+	s.Require().NoError(err)
+	s.Require().Len(users, 1)
+	s.Equal("dir-2", users[0].UserID)
+	s.Equal(1, users[0].OpenReviews)
+}